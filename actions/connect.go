@@ -1,255 +1,180 @@
-package actions
-
-import (
-	"errors"
-	"time"
-
-	"github.com/meetm/linkedin-automation-go/pkg/logger"
-	"github.com/meetm/linkedin-automation-go/utils"
-
-	"github.com/go-rod/rod"
-)
-
-var (
-	ErrAlreadyConnected = errors.New("already connected or pending")
-	ErrFollowOnly       = errors.New("profile only allows follow")
-	ErrRateLimited      = errors.New("rate limited by LinkedIn")
-	ErrConnectFailed    = errors.New("failed to send connection request")
-)
-
-type ConnectionResult struct {
-	ProfileURL string
-	Success    bool
-	Error      error
-	Skipped    bool
-	Reason     string
-}
-
-func SendConnectionRequest(page *rod.Page, profileURL, message string, log *logger.Logger) ConnectionResult {
-	result := ConnectionResult{ProfileURL: profileURL}
-
-	log.Printf("Visiting: %s", profileURL)
-
-	if err := page.Navigate(profileURL); err != nil {
-		result.Error = err
-		log.Printf("Navigation failed: %v", err)
-		return result
-	}
-
-	utils.LongRandomSleep(2, 4)
-	page.MustWaitStable()
-
-	if isAlreadyConnected(page) {
-		result.Skipped = true
-		result.Reason = "already connected"
-		log.Printf("Skipping: already connected")
-		return result
-	}
-
-	if isPending(page) {
-		result.Skipped = true
-		result.Reason = "pending request"
-		log.Printf("Skipping: pending request exists")
-		return result
-	}
-
-	connectBtn := findConnectButton(page)
-	if connectBtn == nil {
-		connectBtn = findConnectInMore(page, log)
-	}
-
-	if connectBtn == nil {
-		result.Skipped = true
-		result.Reason = "no connect option"
-		result.Error = ErrFollowOnly
-		log.Printf("Skipping: no connect button found")
-		return result
-	}
-
-	log.Printf("Clicking connect...")
-	if err := utils.HumanClick(page, connectBtn); err != nil {
-		result.Error = err
-		return result
-	}
-
-	utils.RandomSleep(800, 1500)
-	page.MustWaitStable()
-
-	if message == "" {
-		log.Printf("No message provided, sending without note...")
-		if sendWithoutNote(page, log) {
-			result.Success = true
-			log.Printf("Request sent (without note)")
-			return result
-		}
-		result.Error = ErrConnectFailed
-		log.Printf("Failed to send without note")
-		return result
-	}
-
-	if !handleConnectionModal(page, message, log) {
-		if sendWithoutNote(page, log) {
-			result.Success = true
-			log.Printf("Request sent (without note - fallback)")
-			return result
-		}
-		result.Error = ErrConnectFailed
-		log.Printf("Failed to complete connection flow")
-		return result
-	}
-
-	result.Success = true
-	log.Printf("Request sent with note")
-	return result
-}
-
-func isAlreadyConnected(page *rod.Page) bool {
-	messageBtn, _ := page.Timeout(2*time.Second).ElementR("button", "Message")
-	connectBtn, _ := page.Timeout(1*time.Second).ElementR("button", "Connect")
-	addBtn, _ := page.Timeout(1*time.Second).ElementR("button", "Add")
-
-	hasMessage := messageBtn != nil && utils.IsElementVisible(messageBtn)
-	hasConnect := connectBtn != nil && utils.IsElementVisible(connectBtn)
-	hasAdd := addBtn != nil && utils.IsElementVisible(addBtn)
-
-	return hasMessage && !hasConnect && !hasAdd
-}
-
-func isPending(page *rod.Page) bool {
-	el, err := page.Timeout(2*time.Second).ElementR("button", "Pending")
-	if err != nil {
-		return false
-	}
-	return utils.IsElementVisible(el)
-}
-
-func findConnectButton(page *rod.Page) *rod.Element {
-	el, err := page.Timeout(3*time.Second).ElementR("button", "Connect")
-	if err == nil && utils.IsElementVisible(el) {
-		return el
-	}
-
-	el, err = page.Timeout(2*time.Second).ElementR("button", "Add")
-	if err == nil && utils.IsElementVisible(el) {
-		return el
-	}
-
-	el, err = page.Timeout(2 * time.Second).Element("button[aria-label*='connect' i]")
-	if err == nil && utils.IsElementVisible(el) {
-		return el
-	}
-
-	el, err = page.Timeout(2 * time.Second).Element("button[aria-label*='Invite' i]")
-	if err == nil && utils.IsElementVisible(el) {
-		return el
-	}
-
-	return nil
-}
-
-func findConnectInMore(page *rod.Page, log *logger.Logger) *rod.Element {
-	moreBtn, err := page.Timeout(3 * time.Second).Element("[aria-label='More actions']")
-	if err != nil {
-		return nil
-	}
-
-	log.Printf("Checking more actions menu...")
-	if err := utils.HumanClick(page, moreBtn); err != nil {
-		return nil
-	}
-
-	utils.RandomSleep(500, 1000)
-
-	connectOption, err := page.Timeout(3*time.Second).ElementR("div[role='button'], span", "Connect")
-	if err != nil {
-		page.Keyboard.Press('\x1b')
-		return nil
-	}
-
-	return connectOption
-}
-
-func handleConnectionModal(page *rod.Page, message string, log *logger.Logger) bool {
-	log.Printf("Looking for 'Add a note' button...")
-
-	addNoteBtn, err := page.Timeout(3*time.Second).ElementR("button", "Add a note")
-	if err != nil {
-		addNoteBtn, err = page.Timeout(2*time.Second).ElementR("button", "Add note")
-		if err != nil {
-			addNoteBtn, err = page.Timeout(2*time.Second).ElementR("button", "Personalize")
-			if err != nil {
-				log.Printf("Could not find 'Add a note' button")
-				return false
-			}
-		}
-	}
-
-	log.Printf("Found add note button, clicking...")
-	if err := utils.HumanClick(page, addNoteBtn); err != nil {
-		log.Printf("Failed to click add note button: %v", err)
-		return false
-	}
-
-	utils.RandomSleep(800, 1500)
-
-	log.Printf("Looking for message textarea...")
-	textarea, err := utils.WaitForElement(page, "textarea[name='message']", 5*time.Second)
-	if err != nil {
-		textarea, err = utils.WaitForElement(page, "textarea#custom-message", 3*time.Second)
-		if err != nil {
-			textarea, err = utils.WaitForElement(page, "textarea", 3*time.Second)
-			if err != nil {
-				log.Printf("Could not find message textarea")
-				return false
-			}
-		}
-	}
-
-	log.Printf("Typing message...")
-	if err := utils.HumanType(page, textarea, message); err != nil {
-		log.Printf("Failed to type message: %v", err)
-		return false
-	}
-
-	utils.RandomSleep(500, 1000)
-
-	log.Printf("Looking for Send button...")
-	sendBtn, err := page.Timeout(3*time.Second).ElementR("button", "^Send$")
-	if err != nil {
-		sendBtn, err = page.Timeout(2*time.Second).ElementR("button", "Send invitation")
-		if err != nil {
-			sendBtn, err = page.Timeout(2*time.Second).ElementR("button", "Send")
-			if err != nil {
-				log.Printf("Could not find Send button")
-				return false
-			}
-		}
-	}
-
-	log.Printf("Clicking Send button...")
-	if err := utils.HumanClick(page, sendBtn); err != nil {
-		log.Printf("Failed to click Send button: %v", err)
-		return false
-	}
-
-	utils.RandomSleep(500, 1000)
-	return true
-}
-
-func sendWithoutNote(page *rod.Page, log *logger.Logger) bool {
-	sendBtn, err := page.Timeout(2*time.Second).ElementR("button", "Send without a note")
-	if err != nil {
-		sendBtn, err = page.Timeout(2*time.Second).ElementR("button", "Send")
-		if err != nil {
-			return false
-		}
-	}
-
-	log.Printf("Sending without note as fallback...")
-	if err := utils.HumanClick(page, sendBtn); err != nil {
-		return false
-	}
-
-	utils.RandomSleep(500, 1000)
-	return true
-}
+package actions
+
+import (
+	"errors"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/outcome"
+	"github.com/meetm/linkedin-automation-go/pkg/profileurl"
+	"github.com/meetm/linkedin-automation-go/utils"
+
+	"github.com/go-rod/rod"
+)
+
+var (
+	ErrAlreadyConnected = errors.New("already connected or pending")
+	ErrFollowOnly       = errors.New("profile only allows follow")
+	ErrNoCardConnect    = errors.New("search card has no connect button")
+	ErrNoteUnavailable  = errors.New("note could not be added")
+	ErrNoteQuota        = errors.New("monthly note quota exhausted")
+)
+
+// NotePolicy says what to do when a note was asked for but cannot be added
+type NotePolicy string
+
+const (
+	NoteFallback NotePolicy = "fallback" // send without the note, the default
+	NoteRequire  NotePolicy = "require"  // fail the invitation
+	NoteSkip     NotePolicy = "skip"     // skip the profile
+)
+
+// why a profile in each state is not invited
+var skipReasons = map[RelationshipState]string{
+	RelationshipFirstDegree:  "already connected",
+	RelationshipPending:      "pending request",
+	RelationshipOutOfNetwork: "out of network",
+	RelationshipRestricted:   "restricted profile",
+	RelationshipUnknown:      "no connect option",
+}
+
+// ConnectionResult is how an invitation attempt ended. Outcome is one of
+// Sent, SentWithoutNote, Unconfirmed, Skipped, NoteUnavailable, NoteRequired
+// or Failed; Error says why it was not sent and Reason why it was skipped.
+type ConnectionResult struct {
+	ProfileURL   string
+	Identity     profileurl.Identity
+	Relationship RelationshipState // unset when sent from a search card
+	Outcome      outcome.Outcome
+	Error        error
+	Reason       string
+	NoteQuota    bool // LinkedIn reported the monthly note quota used up
+
+	// the step the flow ended on and how it ended there
+	Step ConnectStep
+	Code StepCode
+}
+
+// SendConnectionRequest visits a profile and invites its owner, with
+// message as the note when it is not empty. policy applies when the note
+// cannot be added.
+func SendConnectionRequest(page *rod.Page, profileURL, message string, policy NotePolicy, log *logger.Logger) ConnectionResult {
+	f := &connectFlow{page: page, message: message, notePolicy: policy, log: log}
+	f.result.ProfileURL = profileURL
+	f.result.Identity, _ = profileurl.Parse(profileURL)
+	return f.run(StepNavigate)
+}
+
+// SendConnectionFromCard sends the invitation from a result card on the
+// current search page, without visiting the profile. It returns
+// ErrNoCardConnect when the card no longer offers Connect.
+func SendConnectionFromCard(page *rod.Page, id profileurl.Identity, message string, policy NotePolicy, log *logger.Logger) ConnectionResult {
+	f := &connectFlow{page: page, message: message, notePolicy: policy, log: log, fromCard: true}
+	f.result.ProfileURL = id.URL()
+	f.result.Identity = id
+
+	log.Printf("Connecting from search card: %s", f.result.ProfileURL)
+	return f.run(StepOpenConnect)
+}
+
+// inviteState is what the page shows after Send was clicked
+type inviteState struct {
+	ModalOpen bool   `json:"modalOpen"`
+	Toast     bool   `json:"toast"`   // "Your invitation was sent" toast
+	Pending   bool   `json:"pending"` // profile top card shows Pending
+	LimitHit  bool   `json:"limitHit"`
+	NoteQuota bool   `json:"noteQuota"` // no personalized invitations left this month
+	Error     string `json:"error"`     // text of an error toast or dialog
+}
+
+const readInviteStateJS = `function() {
+	const dialog = Array.from(document.querySelectorAll('div[role="dialog"], [data-test-modal-id="send-invite-modal"]'))
+		.find(d => d.offsetParent !== null && /add a note|send without a note|send invitation|personalize/i.test(d.innerText));
+	const toast = Array.from(document.querySelectorAll('.artdeco-toast-item, [data-test-artdeco-toast-item-type]'))
+		.some(t => /invitation.*sent|sent.*invitation/i.test(t.innerText));
+	// only dialogs and toasts, so a post or headline quoting these words does not count
+	const notices = Array.from(document.querySelectorAll('div[role="dialog"], div[role="alertdialog"], .artdeco-modal, .artdeco-toast-item, [data-test-artdeco-toast-item-type]'))
+		.filter(e => e.getClientRects().length > 0);
+	const limitHit = notices.some(e =>
+		/(you.ve )?reached the weekly invitation limit|you.re out of invitations for (now|the week)/i.test(e.innerText));
+	const failure = notices.find(e =>
+		(e.matches('.artdeco-toast-item--error, [data-test-artdeco-toast-item-type="error"], div[role="alertdialog"]')) &&
+		/something went wrong|unable to|couldn.t|could not|try again/i.test(e.innerText));
+	const noteQuota = /(used all|no more|0)( of)?( your)?( free)? personalized (invitations|notes)|personalized invitation limit|can.t add (a|any more) notes?/i
+		.test(dialog ? dialog.innerText : document.body.innerText);
+	let pending = false;
+	if (location.pathname.startsWith('/in/')) {
+		const card = document.querySelector('main section');
+		pending = !!card && Array.from(card.querySelectorAll('button')).some(b =>
+			b.offsetParent !== null && /^pending/i.test(b.innerText.trim() || b.getAttribute('aria-label') || ''));
+	}
+	return {modalOpen: !!dialog, toast, pending, limitHit, noteQuota, error: failure ? failure.innerText.trim() : ''};
+}`
+
+func readInviteState(page *rod.Page) inviteState {
+	var s inviteState
+	if obj, err := page.Eval(readInviteStateJS); err == nil {
+		obj.Value.Unmarshal(&s)
+	}
+	return s
+}
+
+func findConnectButton(page *rod.Page) *rod.Element {
+	el, err := page.Timeout(3*time.Second).ElementR("button", "Connect")
+	if err == nil && utils.IsElementVisible(el) {
+		return el
+	}
+
+	el, err = page.Timeout(2*time.Second).ElementR("button", "Add")
+	if err == nil && utils.IsElementVisible(el) {
+		return el
+	}
+
+	el, err = page.Timeout(2 * time.Second).Element("button[aria-label*='connect' i]")
+	if err == nil && utils.IsElementVisible(el) {
+		return el
+	}
+
+	el, err = page.Timeout(2 * time.Second).Element("button[aria-label*='Invite' i]")
+	if err == nil && utils.IsElementVisible(el) {
+		return el
+	}
+
+	return nil
+}
+
+func findConnectInMore(page *rod.Page, log *logger.Logger) *rod.Element {
+	moreBtn, err := page.Timeout(3 * time.Second).Element("[aria-label='More actions']")
+	if err != nil {
+		return nil
+	}
+
+	log.Printf("Checking more actions menu...")
+	if err := utils.HumanClick(page, moreBtn); err != nil {
+		return nil
+	}
+
+	utils.RandomSleep(500, 1000)
+
+	connectOption, err := page.Timeout(3*time.Second).ElementR("div[role='button'], span", "Connect")
+	if err != nil {
+		page.Keyboard.Press('\x1b')
+		return nil
+	}
+
+	return connectOption
+}
+
+// findFirst returns the first of several button text patterns found on the page
+func findFirst(page *rod.Page, patterns ...string) *rod.Element {
+	for i, pattern := range patterns {
+		wait := 2 * time.Second
+		if i == 0 {
+			wait = 3 * time.Second
+		}
+		if el, err := page.Timeout(wait).ElementR("button", pattern); err == nil {
+			return el
+		}
+	}
+	return nil
+}
@@ -0,0 +1,356 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/outcome"
+	"github.com/meetm/linkedin-automation-go/pkg/profileurl"
+	"github.com/meetm/linkedin-automation-go/search"
+	"github.com/meetm/linkedin-automation-go/utils"
+
+	"github.com/go-rod/rod"
+)
+
+// ConnectStep is one stage of the connection flow:
+// Navigate → Classify → OpenConnect → ChooseNote → TypeNote → Send → Verify.
+// Invitations from search cards start at OpenConnect.
+type ConnectStep string
+
+const (
+	StepNavigate    ConnectStep = "navigate"
+	StepClassify    ConnectStep = "classify"
+	StepOpenConnect ConnectStep = "open-connect"
+	StepChooseNote  ConnectStep = "choose-note"
+	StepTypeNote    ConnectStep = "type-note"
+	StepSend        ConnectStep = "send"
+	StepVerify      ConnectStep = "verify"
+
+	stepDone ConnectStep = ""
+)
+
+// StepCode is how a step of the connection flow ended
+type StepCode string
+
+const (
+	CodeOK          StepCode = "ok"
+	CodeSkipped     StepCode = "skipped"   // the profile is not to be invited
+	CodeNotFound    StepCode = "not-found" // an element the step needs is missing
+	CodeTimeout     StepCode = "timeout"
+	CodeFailed      StepCode = "failed"
+	CodeUnconfirmed StepCode = "unconfirmed"
+)
+
+// connectStep is a step's work and the policy it runs under. A step that
+// ends not-found, or timeout when it is safe to repeat, is retried.
+type connectStep struct {
+	timeout    time.Duration
+	retries    int
+	repeatable bool // has no effect on the page that retrying could double
+	run        func(f *connectFlow, p *rod.Page) (ConnectStep, StepCode, error)
+}
+
+var connectSteps = map[ConnectStep]connectStep{
+	StepNavigate:    {timeout: 90 * time.Second, repeatable: true, run: (*connectFlow).navigate}, // retries within utils.Navigate
+	StepClassify:    {timeout: 30 * time.Second, retries: 1, repeatable: true, run: (*connectFlow).classify},
+	StepOpenConnect: {timeout: 20 * time.Second, retries: 1, run: (*connectFlow).openConnect},
+	StepChooseNote:  {timeout: 15 * time.Second, run: (*connectFlow).chooseNote},
+	StepTypeNote:    {timeout: 90 * time.Second, run: (*connectFlow).typeNote},
+	StepSend:        {timeout: 15 * time.Second, retries: 1, run: (*connectFlow).send},
+	StepVerify:      {timeout: 10 * time.Second, run: (*connectFlow).verify},
+}
+
+// StepEvent is logged as a "connect_step" event after every step attempt
+type StepEvent struct {
+	ProfileURL string      `json:"profileUrl"`
+	Step       ConnectStep `json:"step"`
+	Attempt    int         `json:"attempt"`
+	Code       StepCode    `json:"code"`
+	Next       ConnectStep `json:"next,omitempty"`
+	Error      string      `json:"error,omitempty"`
+	ElapsedMs  int64       `json:"elapsedMs"`
+}
+
+// connectFlow is the state carried between the steps of one invitation.
+// Elements are never carried over: each step finds what it acts on, under
+// its own timeout.
+type connectFlow struct {
+	page       *rod.Page
+	message    string
+	notePolicy NotePolicy
+	log        *logger.Logger
+	fromCard   bool
+	result     ConnectionResult
+
+	hadModal    bool   // the invitation modal opened after clicking Connect
+	withNote    bool   // the note was typed, so Send is the note's Send button
+	withoutNote bool   // the note was asked for but is left out
+	how         string // how the invitation is sent, for the log
+}
+
+// run executes steps from start until the flow ends, recording the step it
+// ended on in the result
+func (f *connectFlow) run(start ConnectStep) ConnectionResult {
+	for step := start; step != stepDone; {
+		spec := connectSteps[step]
+
+		var next ConnectStep
+		var code StepCode
+		var err error
+		for attempt := 1; ; attempt++ {
+			began := time.Now()
+			p := f.page.Timeout(spec.timeout)
+			next, code, err = spec.run(f, p)
+			p.CancelTimeout()
+			if errors.Is(err, context.DeadlineExceeded) {
+				code = CodeTimeout
+			}
+
+			ev := StepEvent{
+				ProfileURL: f.result.ProfileURL,
+				Step:       step,
+				Attempt:    attempt,
+				Code:       code,
+				Next:       next,
+				ElapsedMs:  time.Since(began).Milliseconds(),
+			}
+			if err != nil {
+				ev.Error = err.Error()
+			}
+			f.log.Event("connect_step", ev)
+
+			retry := code == CodeNotFound || code == CodeTimeout && spec.repeatable
+			if !retry || attempt > spec.retries {
+				break
+			}
+			utils.RandomSleep(1000, 2000)
+		}
+
+		f.result.Step, f.result.Code = step, code
+		if code != CodeOK {
+			if err != nil && f.result.Error == nil {
+				f.result.Error = err
+			}
+			break
+		}
+		step = next
+	}
+	if f.result.Outcome == "" {
+		f.result.Outcome = outcome.Failed
+	}
+	return f.result
+}
+
+func (f *connectFlow) navigate(p *rod.Page) (ConnectStep, StepCode, error) {
+	f.log.Printf("Visiting: %s", f.result.ProfileURL)
+	if err := utils.Navigate(p, f.result.ProfileURL, f.log); err != nil {
+		return stepDone, CodeFailed, err
+	}
+
+	utils.LongRandomSleep(2, 4)
+	if err := utils.WaitStable(p, time.Second, f.log); err != nil {
+		return stepDone, CodeFailed, err
+	}
+
+	// member-ID URLs redirect to the vanity URL, which tells us both identities
+	if info, err := p.Info(); err == nil {
+		if landed, err := profileurl.Parse(info.URL); err == nil {
+			f.result.Identity = f.result.Identity.Merge(landed)
+		}
+	}
+	return StepClassify, CodeOK, nil
+}
+
+func (f *connectFlow) classify(p *rod.Page) (ConnectStep, StepCode, error) {
+	state, err := ClassifyRelationship(p, f.log)
+	if err != nil {
+		return stepDone, CodeFailed, err
+	}
+	f.result.Relationship = state
+
+	switch state {
+	case RelationshipConnectable, RelationshipConnectableViaMore:
+		return StepOpenConnect, CodeOK, nil
+	case RelationshipFollowOnly:
+		f.result.Error = ErrFollowOnly
+		f.result.Reason = "no connect option"
+	default:
+		f.result.Reason = skipReasons[state]
+	}
+	f.result.Outcome = outcome.Skipped
+	f.log.Printf("Skipping: %s", f.result.Reason)
+	return stepDone, CodeSkipped, nil
+}
+
+func (f *connectFlow) openConnect(p *rod.Page) (ConnectStep, StepCode, error) {
+	var connectBtn *rod.Element
+	switch {
+	case f.fromCard:
+		if connectBtn = search.ConnectButton(p, f.result.Identity); connectBtn == nil {
+			return stepDone, CodeNotFound, ErrNoCardConnect
+		}
+	case f.result.Relationship == RelationshipConnectableViaMore:
+		connectBtn = findConnectInMore(p, f.log)
+	default:
+		connectBtn = findConnectButton(p)
+	}
+	if connectBtn == nil {
+		return stepDone, CodeNotFound, &outcome.SelectorNotFound{Element: "connect button"}
+	}
+
+	f.log.Printf("Clicking connect...")
+	if err := utils.HumanClick(p, connectBtn); err != nil {
+		return stepDone, CodeFailed, err
+	}
+
+	utils.RandomSleep(800, 1500)
+	if err := utils.WaitStable(p, time.Second, f.log); err != nil {
+		return stepDone, CodeFailed, err
+	}
+	f.hadModal = readInviteState(p).ModalOpen
+	return StepChooseNote, CodeOK, nil
+}
+
+// chooseNote opens the note editor, applying the note policy when the
+// modal offers none
+func (f *connectFlow) chooseNote(p *rod.Page) (ConnectStep, StepCode, error) {
+	if f.message == "" {
+		f.log.Printf("No message provided, sending without note...")
+		f.how = "without note"
+		return StepSend, CodeOK, nil
+	}
+
+	addNoteBtn := findFirst(p, "Add a note", "Add note", "Personalize")
+	if addNoteBtn == nil {
+		return f.noNote(p)
+	}
+	if err := utils.HumanClick(p, addNoteBtn); err != nil {
+		f.log.Printf("Failed to click add note button: %v", err)
+		return f.noNote(p)
+	}
+
+	utils.RandomSleep(800, 1500)
+	if readInviteState(p).NoteQuota {
+		return f.noNote(p)
+	}
+	return StepTypeNote, CodeOK, nil
+}
+
+// noNote applies the note policy once the note turned out not to be possible
+func (f *connectFlow) noNote(p *rod.Page) (ConnectStep, StepCode, error) {
+	err := ErrNoteUnavailable
+	if readInviteState(p).NoteQuota {
+		err = ErrNoteQuota
+		f.result.NoteQuota = true
+	}
+
+	switch f.notePolicy {
+	case NoteRequire:
+		f.log.Printf("%v, not sending without it", err)
+		p.Keyboard.Press('\x1b')
+		f.result.Outcome = outcome.NoteRequired
+		return stepDone, CodeFailed, err
+	case NoteSkip:
+		f.log.Printf("Skipping: %v", err)
+		p.Keyboard.Press('\x1b')
+		f.result.Outcome = outcome.NoteUnavailable
+		f.result.Reason = err.Error()
+		f.result.Error = err
+		return stepDone, CodeSkipped, nil
+	}
+
+	f.log.Printf("%v, sending without note", err)
+	f.how = "without note - fallback"
+	f.withoutNote = true
+	return StepSend, CodeOK, nil
+}
+
+func (f *connectFlow) typeNote(p *rod.Page) (ConnectStep, StepCode, error) {
+	var textarea *rod.Element
+	var err error
+	for i, sel := range []string{"textarea[name='message']", "textarea#custom-message", "textarea"} {
+		wait := 3 * time.Second
+		if i == 0 {
+			wait = 5 * time.Second
+		}
+		if textarea, err = utils.WaitForElement(p, sel, wait); err == nil {
+			break
+		}
+	}
+	if textarea == nil {
+		f.log.Printf("Could not find message textarea")
+		return f.noNote(p)
+	}
+
+	f.log.Printf("Typing message...")
+	if err := utils.HumanType(p, textarea, f.message); err != nil {
+		return stepDone, CodeFailed, err
+	}
+	utils.RandomSleep(500, 1000)
+
+	f.withNote = true
+	f.how = "with note"
+	return StepSend, CodeOK, nil
+}
+
+func (f *connectFlow) send(p *rod.Page) (ConnectStep, StepCode, error) {
+	var sendBtn *rod.Element
+	if f.withNote {
+		sendBtn = findFirst(p, "^Send$", "Send invitation", "Send")
+	} else {
+		sendBtn = findFirst(p, "Send without a note", "Send")
+	}
+	if sendBtn == nil {
+		return stepDone, CodeNotFound, &outcome.SelectorNotFound{Element: "send button"}
+	}
+
+	f.log.Printf("Clicking Send button...")
+	if err := utils.HumanClick(p, sendBtn); err != nil {
+		return stepDone, CodeFailed, err
+	}
+	utils.RandomSleep(500, 1000)
+	return StepVerify, CodeOK, nil
+}
+
+// verify waits for the invitation modal to close, the profile to show
+// Pending or the confirmation toast. A click that shows none of these is
+// reported as unconfirmed rather than as a success. The limit warning and
+// error messages are checked first, since the modal closes with them too.
+func (f *connectFlow) verify(p *rod.Page) (ConnectStep, StepCode, error) {
+	deadline := time.Now().Add(6 * time.Second)
+	for {
+		s := readInviteState(p)
+		switch {
+		case s.LimitHit:
+			f.log.Printf("Invitation limit reached")
+			p.Keyboard.Press('\x1b')
+			return stepDone, CodeFailed, outcome.ErrRateLimited
+		case s.Error != "":
+			f.log.Printf("LinkedIn refused the invitation: %s", s.Error)
+			p.Keyboard.Press('\x1b')
+			return stepDone, CodeFailed, fmt.Errorf("invitation not sent: %s", s.Error)
+		case s.Toast, s.Pending, f.hadModal && !s.ModalOpen:
+			f.result.Outcome = outcome.Sent
+			if f.withoutNote {
+				f.result.Outcome = outcome.SentWithoutNote
+			}
+			f.log.Printf("Request sent (%s)", f.how)
+			return stepDone, CodeOK, nil
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	f.result.Outcome = outcome.Unconfirmed
+	f.result.Reason = "send not confirmed"
+	f.log.Printf("Send clicked (%s) but the invitation could not be confirmed", f.how)
+	if readInviteState(p).ModalOpen {
+		p.Keyboard.Press('\x1b')
+	}
+	return stepDone, CodeUnconfirmed, nil
+}
@@ -0,0 +1,97 @@
+package actions
+
+import (
+	"errors"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/utils"
+
+	"github.com/go-rod/rod"
+)
+
+var (
+	ErrAlreadyFollowing = errors.New("already following")
+	ErrNoFollow         = errors.New("profile has no follow option")
+)
+
+// Follow follows the profile open in page, using its Follow button or the
+// More actions menu. Call it after SendConnectionRequest returns ErrFollowOnly.
+func Follow(page *rod.Page, log *logger.Logger) error {
+	if isFollowing(page) {
+		return ErrAlreadyFollowing
+	}
+
+	followBtn := findFollowButton(page)
+	if followBtn == nil {
+		followBtn = findFollowInMore(page, log)
+	}
+	if followBtn == nil {
+		return ErrNoFollow
+	}
+
+	log.Printf("Clicking follow...")
+	if err := utils.HumanClick(page, followBtn); err != nil {
+		return err
+	}
+	utils.RandomSleep(800, 1500)
+	return nil
+}
+
+// FollowProfile visits a profile and follows it
+func FollowProfile(page *rod.Page, profileURL string, log *logger.Logger) error {
+	log.Printf("Visiting: %s", profileURL)
+	if err := utils.Navigate(page, profileURL, log); err != nil {
+		return err
+	}
+	utils.LongRandomSleep(2, 4)
+	if err := utils.WaitStable(page, time.Second, log); err != nil {
+		return err
+	}
+
+	return Follow(page, log)
+}
+
+func isFollowing(page *rod.Page) bool {
+	el, err := page.Timeout(2*time.Second).ElementR("button", "^Following$")
+	if err != nil {
+		return false
+	}
+	return utils.IsElementVisible(el)
+}
+
+func findFollowButton(page *rod.Page) *rod.Element {
+	el, err := page.Timeout(2*time.Second).ElementR("button", "^Follow$")
+	if err == nil && utils.IsElementVisible(el) {
+		return el
+	}
+
+	el, err = page.Timeout(2 * time.Second).Element("button[aria-label^='Follow ']")
+	if err == nil && utils.IsElementVisible(el) {
+		return el
+	}
+
+	return nil
+}
+
+func findFollowInMore(page *rod.Page, log *logger.Logger) *rod.Element {
+	moreBtn, err := page.Timeout(3 * time.Second).Element("[aria-label='More actions']")
+	if err != nil {
+		return nil
+	}
+
+	log.Printf("Checking more actions menu for follow...")
+	if err := utils.HumanClick(page, moreBtn); err != nil {
+		return nil
+	}
+
+	utils.RandomSleep(500, 1000)
+
+	followOption, err := page.Timeout(3*time.Second).ElementR("div[role='button'], span", "^Follow$")
+	if err != nil {
+		page.Keyboard.Press('\x1b')
+		return nil
+	}
+
+	return followOption
+}
@@ -0,0 +1,316 @@
+package actions
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/profileurl"
+	"github.com/meetm/linkedin-automation-go/utils"
+
+	"github.com/go-rod/rod"
+)
+
+const sentCardSelector = "li.invitation-card, li[class*='invitation'], div[data-view-name*='invitation']"
+
+const (
+	SentInvitationsURL = "https://www.linkedin.com/mynetwork/invitation-manager/sent/"
+	ConnectionsURL     = "https://www.linkedin.com/mynetwork/invite-connect/connections/"
+)
+
+// InvitationState is what a profile page says about an invitation we sent
+type InvitationState string
+
+const (
+	InvitationPending  InvitationState = "pending"
+	InvitationAccepted InvitationState = "accepted"
+	InvitationGone     InvitationState = "gone" // neither pending nor connected
+	InvitationUnknown  InvitationState = "unknown"
+)
+
+var ErrNoWithdraw = errors.New("no pending invitation to withdraw")
+
+// SentInvitation is one row of the Sent Invitations manager
+type SentInvitation struct {
+	Identity profileurl.Identity
+	Name     string
+	Sent     string        // as shown by LinkedIn, e.g. "Sent 3 weeks ago"
+	Age      time.Duration // parsed from Sent, zero when unknown
+}
+
+var agePattern = regexp.MustCompile(`(?i)(\d+|an?)\s+(minute|hour|day|week|month|year)s?\s+ago`)
+
+// ParseAge converts LinkedIn's "Sent 3 weeks ago" style badges to a duration.
+// Months and years are approximate, which is all LinkedIn shows.
+func ParseAge(s string) (time.Duration, bool) {
+	lower := strings.ToLower(s)
+	if strings.Contains(lower, "today") || strings.Contains(lower, "just now") {
+		return 0, true
+	}
+	if strings.Contains(lower, "yesterday") {
+		return 24 * time.Hour, true
+	}
+
+	m := agePattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		n = 1 // "a week ago"
+	}
+
+	day := 24 * time.Hour
+	unit := map[string]time.Duration{
+		"minute": time.Minute,
+		"hour":   time.Hour,
+		"day":    day,
+		"week":   7 * day,
+		"month":  30 * day,
+		"year":   365 * day,
+	}[strings.ToLower(m[2])]
+	return time.Duration(n) * unit, true
+}
+
+// reads the profile links of every card in a list, with its name and time badge
+const readListJS = `function(sel) {
+	return Array.from(document.querySelectorAll(sel)).map(card => {
+		const link = card.querySelector("a[href*='/in/']");
+		const name = card.querySelector("[class*='title'], [class*='name']");
+		const time = card.querySelector("time, [class*='time-badge']");
+		return {
+			href: link ? link.getAttribute('href') : '',
+			name: name ? name.innerText.trim() : '',
+			time: time ? time.innerText.trim() : '',
+		};
+	});
+}`
+
+type listRow struct {
+	Href string `json:"href"`
+	Name string `json:"name"`
+	Time string `json:"time"`
+}
+
+// SentInvitations lists the pending invitations in the Sent Invitations
+// manager, scrolling and paging until no new rows appear
+func SentInvitations(page *rod.Page, log *logger.Logger) ([]SentInvitation, error) {
+	log.Printf("Opening sent invitations...")
+	if err := utils.Navigate(page, SentInvitationsURL, log); err != nil {
+		return nil, err
+	}
+	utils.LongRandomSleep(2, 4)
+	if err := utils.WaitStable(page, time.Second, log); err != nil {
+		return nil, err
+	}
+
+	var sent []SentInvitation
+	for pageNum := 1; pageNum <= 50; pageNum++ {
+		rows := scrollList(page, sentCardSelector, 10)
+		for _, row := range rows {
+			id, err := profileurl.Parse(row.Href)
+			if err != nil || containsIdentity(sent, id) {
+				continue
+			}
+			inv := SentInvitation{Identity: id, Name: utils.FirstLine(row.Name), Sent: row.Time}
+			inv.Age, _ = ParseAge(row.Time)
+			sent = append(sent, inv)
+		}
+
+		next, err := page.Timeout(2 * time.Second).Element("button[aria-label='Next']")
+		if err != nil {
+			break
+		}
+		if disabled, _ := next.Attribute("disabled"); disabled != nil {
+			break
+		}
+		if err := utils.HumanClick(page, next); err != nil {
+			break
+		}
+		utils.LongRandomSleep(2, 3)
+		if err := utils.WaitStable(page, time.Second, log); err != nil {
+			log.Printf("Next page did not load: %v", err)
+			break
+		}
+	}
+
+	log.Printf("Found %d pending invitations", len(sent))
+	return sent, nil
+}
+
+// RecentConnections returns the most recently added connections, scrolling
+// the connections list at most scrolls times
+func RecentConnections(page *rod.Page, scrolls int, log *logger.Logger) ([]profileurl.Identity, error) {
+	log.Printf("Opening connections...")
+	if err := utils.Navigate(page, ConnectionsURL, log); err != nil {
+		return nil, err
+	}
+	utils.LongRandomSleep(2, 4)
+	if err := utils.WaitStable(page, time.Second, log); err != nil {
+		return nil, err
+	}
+
+	var ids []profileurl.Identity
+	for _, row := range scrollList(page, "li.mn-connection-card, li[class*='connection-card'], div[data-view-name*='connection']", scrolls) {
+		id, err := profileurl.Parse(row.Href)
+		if err != nil {
+			continue
+		}
+		seen := false
+		for _, known := range ids {
+			if known.Matches(id) {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			ids = append(ids, id)
+		}
+	}
+
+	log.Printf("Read %d recent connections", len(ids))
+	return ids, nil
+}
+
+// CheckInvitation visits a profile and reports whether our invitation is
+// still pending, was accepted, or is gone
+func CheckInvitation(page *rod.Page, profileURL string, log *logger.Logger) (InvitationState, error) {
+	log.Printf("Checking: %s", profileURL)
+	if err := utils.Navigate(page, profileURL, log); err != nil {
+		return InvitationUnknown, err
+	}
+	utils.LongRandomSleep(2, 4)
+	if err := utils.WaitStable(page, time.Second, log); err != nil {
+		return InvitationUnknown, err
+	}
+
+	state, err := ClassifyRelationship(page, log)
+	if err != nil {
+		return InvitationUnknown, err
+	}
+	switch state {
+	case RelationshipPending:
+		return InvitationPending, nil
+	case RelationshipFirstDegree:
+		return InvitationAccepted, nil
+	case RelationshipConnectable, RelationshipConnectableViaMore, RelationshipFollowOnly:
+		return InvitationGone, nil
+	}
+	return InvitationUnknown, nil
+}
+
+// WithdrawInvitation withdraws a pending invitation, from its card when the
+// Sent Invitations manager is open and shows it, otherwise from the profile
+func WithdrawInvitation(page *rod.Page, id profileurl.Identity, log *logger.Logger) error {
+	btn := sentCardWithdrawButton(page, id)
+	if btn == nil {
+		log.Printf("Visiting: %s", id.URL())
+		if err := utils.Navigate(page, id.URL(), log); err != nil {
+			return err
+		}
+		utils.LongRandomSleep(2, 4)
+		if err := utils.WaitStable(page, time.Second, log); err != nil {
+			return err
+		}
+
+		el, err := page.Timeout(3*time.Second).ElementR("button", "Pending")
+		if err != nil || !utils.IsElementVisible(el) {
+			return ErrNoWithdraw
+		}
+		btn = el
+	}
+
+	log.Printf("Withdrawing invitation...")
+	if err := utils.HumanClick(page, btn); err != nil {
+		return err
+	}
+	utils.RandomSleep(800, 1500)
+
+	confirm, err := page.Timeout(5*time.Second).ElementR("div[role='dialog'] button, div[role='alertdialog'] button", "^Withdraw$")
+	if err != nil {
+		page.Keyboard.Press('\x1b')
+		return ErrNoWithdraw
+	}
+	if err := utils.HumanClick(page, confirm); err != nil {
+		return err
+	}
+	utils.RandomSleep(1000, 2000)
+	return nil
+}
+
+// sentCardWithdrawButton returns the Withdraw button of the card for id on
+// the Sent Invitations manager, if that is the current page
+func sentCardWithdrawButton(page *rod.Page, id profileurl.Identity) *rod.Element {
+	info, err := page.Info()
+	if err != nil || !strings.Contains(info.URL, "/invitation-manager/sent") {
+		return nil
+	}
+	cards, err := page.Elements(sentCardSelector)
+	if err != nil {
+		return nil
+	}
+	for _, card := range cards {
+		link, err := card.Element("a[href*='/in/']")
+		if err != nil {
+			continue
+		}
+		href, _ := link.Attribute("href")
+		if href == nil {
+			continue
+		}
+		if cardID, err := profileurl.Parse(*href); err != nil || !cardID.Matches(id) {
+			continue
+		}
+		btn, err := card.ElementR("button", "Withdraw")
+		if err != nil {
+			return nil
+		}
+		return btn
+	}
+	return nil
+}
+
+// scrollList scrolls a lazily loaded list, clicking "Show more" when offered,
+// until it stops growing or scrolls runs out, and returns the rows seen
+func scrollList(page *rod.Page, cardSelector string, scrolls int) []listRow {
+	rows := readList(page, cardSelector)
+	for i := 0; i < scrolls; i++ {
+		if more, err := page.Timeout(time.Second).ElementR("button", "(?i)^show more"); err == nil && utils.IsElementVisible(more) {
+			utils.HumanClick(page, more)
+		} else {
+			utils.HumanScroll(page, 1200)
+		}
+		utils.RandomSleep(800, 1500)
+
+		batch := readList(page, cardSelector)
+		if len(batch) <= len(rows) {
+			break
+		}
+		rows = batch
+	}
+	return rows
+}
+
+func readList(page *rod.Page, cardSelector string) []listRow {
+	obj, err := page.Eval(readListJS, cardSelector)
+	if err != nil {
+		return nil
+	}
+	var rows []listRow
+	if err := obj.Value.Unmarshal(&rows); err != nil {
+		return nil
+	}
+	return rows
+}
+
+func containsIdentity(sent []SentInvitation, id profileurl.Identity) bool {
+	for _, s := range sent {
+		if s.Identity.Matches(id) {
+			return true
+		}
+	}
+	return false
+}
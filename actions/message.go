@@ -0,0 +1,121 @@
+package actions
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/outcome"
+	"github.com/meetm/linkedin-automation-go/utils"
+
+	"github.com/go-rod/rod"
+)
+
+var (
+	ErrNoMessageButton = errors.New("profile has no message button")
+)
+
+// reads the sender of each message group in the open conversation
+const readSendersJS = `function() {
+	return Array.from(document.querySelectorAll('.msg-s-message-group__name, .msg-s-message-group__profile-link'))
+		.map(el => el.innerText.trim())
+		.filter(Boolean);
+}`
+
+// SendMessage opens a conversation with a 1st-degree connection from their
+// profile and sends text
+func SendMessage(page *rod.Page, profileURL, text string, log *logger.Logger) error {
+	composer, err := openConversation(page, profileURL, log)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Typing message...")
+	if err := utils.HumanType(page, composer, text); err != nil {
+		return err
+	}
+	utils.RandomSleep(500, 1000)
+
+	sendBtn, err := page.Timeout(3 * time.Second).Element("button.msg-form__send-button, button[type='submit'].msg-form__send-btn")
+	if err != nil {
+		sendBtn, err = page.Timeout(2*time.Second).ElementR("div[class*='msg-form'] button", "^Send$")
+		if err != nil {
+			closeConversation(page)
+			return &outcome.SelectorNotFound{Element: "message send button"}
+		}
+	}
+
+	log.Printf("Sending message...")
+	if err := utils.HumanClick(page, sendBtn); err != nil {
+		return err
+	}
+	utils.RandomSleep(1000, 2000)
+
+	closeConversation(page)
+	return nil
+}
+
+// HasReplied opens the conversation with a connection and reports whether
+// any message in it was written by them
+func HasReplied(page *rod.Page, profileURL, name string, log *logger.Logger) (bool, error) {
+	if _, err := openConversation(page, profileURL, log); err != nil {
+		return false, err
+	}
+	defer closeConversation(page)
+
+	obj, err := page.Eval(readSendersJS)
+	if err != nil {
+		return false, err
+	}
+	var senders []string
+	if err := obj.Value.Unmarshal(&senders); err != nil {
+		return false, err
+	}
+
+	first, _, _ := strings.Cut(strings.TrimSpace(name), " ")
+	for _, sender := range senders {
+		if first != "" && strings.HasPrefix(strings.ToLower(sender), strings.ToLower(first)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// openConversation visits the profile, clicks Message and returns the composer
+func openConversation(page *rod.Page, profileURL string, log *logger.Logger) (*rod.Element, error) {
+	log.Printf("Visiting: %s", profileURL)
+	if err := utils.Navigate(page, profileURL, log); err != nil {
+		return nil, err
+	}
+	utils.LongRandomSleep(2, 4)
+	if err := utils.WaitStable(page, time.Second, log); err != nil {
+		return nil, err
+	}
+
+	msgBtn, err := page.Timeout(3*time.Second).ElementR("button", "^Message$")
+	if err != nil || !utils.IsElementVisible(msgBtn) {
+		return nil, ErrNoMessageButton
+	}
+	if err := utils.HumanClick(page, msgBtn); err != nil {
+		return nil, err
+	}
+	utils.RandomSleep(1000, 2000)
+
+	composer, err := utils.WaitForElement(page, "div.msg-form__contenteditable[contenteditable='true']", 5*time.Second)
+	if err != nil {
+		composer, err = utils.WaitForElement(page, "div[role='textbox'][contenteditable='true']", 3*time.Second)
+		if err != nil {
+			return nil, &outcome.SelectorNotFound{Element: "message composer"}
+		}
+	}
+	return composer, nil
+}
+
+func closeConversation(page *rod.Page) {
+	if btn, err := page.Timeout(2*time.Second).ElementR("button", "Close your conversation"); err == nil {
+		utils.HumanClick(page, btn)
+		return
+	}
+	page.Keyboard.Press('\x1b')
+}
@@ -0,0 +1,196 @@
+package actions
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/utils"
+
+	"github.com/go-rod/rod"
+)
+
+// RelationshipState is how we stand with the owner of a profile, and what
+// the profile lets us do about it
+type RelationshipState string
+
+const (
+	RelationshipFirstDegree        RelationshipState = "first-degree"
+	RelationshipPending            RelationshipState = "pending"
+	RelationshipConnectable        RelationshipState = "connectable"
+	RelationshipConnectableViaMore RelationshipState = "connectable-via-more"
+	RelationshipFollowOnly         RelationshipState = "follow-only"
+	RelationshipOutOfNetwork       RelationshipState = "out-of-network"
+	RelationshipRestricted         RelationshipState = "restricted" // unavailable, or offers neither Connect nor Follow
+	RelationshipUnknown            RelationshipState = "unknown"
+)
+
+// ProfileSignals is what a profile page shows about our relationship
+type ProfileSignals struct {
+	Degree      string   // "1st", "2nd", "3rd", "3rd+", "out of network" or empty
+	Buttons     []string // lower-case labels and aria-labels of the top card buttons
+	More        []string // the same for the More actions menu; nil until it is read
+	Unavailable bool     // the profile does not exist or is hidden from us
+}
+
+var profileDegreePattern = regexp.MustCompile(`\b(1st|2nd|3rd\+?)\b`)
+
+// reads the degree badge and button labels of the profile's top card
+const readSignalsJS = `function() {
+	const body = document.body.innerText;
+	const h1 = document.querySelector('main h1');
+	const unavailable = /This profile is not available|This page doesn.t exist/.test(body) ||
+		(h1 && h1.innerText.trim() === 'LinkedIn Member');
+
+	const card = document.querySelector('main section') || document;
+	const badge = card.querySelector('.dist-value, .distance-badge, [class*="distance-badge"]');
+	const labels = [];
+	card.querySelectorAll('button, a[role="button"]').forEach(el => {
+		if (el.offsetParent === null) return;
+		[el.innerText, el.getAttribute('aria-label')].forEach(l => {
+			if (l && l.trim()) labels.push(l.trim().toLowerCase());
+		});
+	});
+	return {
+		degree: badge ? badge.innerText.trim() : '',
+		outOfNetwork: /out of network/i.test(card.innerText || ''),
+		buttons: labels,
+		unavailable: !!unavailable,
+	};
+}`
+
+// reads the labels of the open More actions menu
+const readMenuJS = `function() {
+	const labels = [];
+	document.querySelectorAll('.artdeco-dropdown__content [role="button"], .artdeco-dropdown__content li').forEach(el => {
+		if (el.offsetParent === null) return;
+		[el.innerText, el.getAttribute('aria-label')].forEach(l => {
+			if (l && l.trim()) labels.push(l.trim().toLowerCase());
+		});
+	});
+	return labels;
+}`
+
+// Classify derives the relationship state from what a profile shows. The
+// degree badge is trusted over the buttons, since Message also shows for
+// 2nd-degree members who accept InMail. Without a decisive badge or button
+// it returns RelationshipUnknown until the More menu has been read.
+func Classify(s ProfileSignals) RelationshipState {
+	switch {
+	case s.Unavailable:
+		return RelationshipRestricted
+	case s.Degree == "1st", hasLabel(s.More, isRemoveConnectionLabel):
+		return RelationshipFirstDegree
+	case hasLabel(s.Buttons, isPendingLabel), hasLabel(s.More, isPendingLabel):
+		return RelationshipPending
+	case hasLabel(s.Buttons, isConnectLabel):
+		return RelationshipConnectable
+	case hasLabel(s.More, isConnectLabel):
+		return RelationshipConnectableViaMore
+	case s.More == nil:
+		return RelationshipUnknown
+	case hasLabel(s.Buttons, isFollowLabel), hasLabel(s.More, isFollowLabel):
+		return RelationshipFollowOnly
+	case s.Degree == "out of network", strings.HasPrefix(s.Degree, "3rd"):
+		return RelationshipOutOfNetwork
+	case s.Degree == "2nd":
+		return RelationshipRestricted
+	}
+	return RelationshipUnknown
+}
+
+// ClassifyRelationship reads the profile open in page and classifies it,
+// opening the More actions menu only when the top card is not decisive
+func ClassifyRelationship(page *rod.Page, log *logger.Logger) (RelationshipState, error) {
+	s, err := readSignals(page)
+	if err != nil {
+		return RelationshipUnknown, err
+	}
+
+	state := Classify(s)
+	if state == RelationshipUnknown && s.More == nil {
+		s.More = readMoreMenu(page, log)
+		state = Classify(s)
+	}
+	log.Printf("Relationship: %s (degree %q)", state, s.Degree)
+	return state, nil
+}
+
+func readSignals(page *rod.Page) (ProfileSignals, error) {
+	obj, err := page.Eval(readSignalsJS)
+	if err != nil {
+		return ProfileSignals{}, err
+	}
+	var raw struct {
+		Degree       string   `json:"degree"`
+		OutOfNetwork bool     `json:"outOfNetwork"`
+		Buttons      []string `json:"buttons"`
+		Unavailable  bool     `json:"unavailable"`
+	}
+	if err := obj.Value.Unmarshal(&raw); err != nil {
+		return ProfileSignals{}, err
+	}
+
+	s := ProfileSignals{
+		Degree:      profileDegreePattern.FindString(raw.Degree),
+		Buttons:     raw.Buttons,
+		Unavailable: raw.Unavailable,
+	}
+	if s.Degree == "" && raw.OutOfNetwork {
+		s.Degree = "out of network"
+	}
+	return s, nil
+}
+
+// readMoreMenu opens the More actions menu, reads its labels and closes it.
+// It returns an empty, non-nil slice when the profile has no menu.
+func readMoreMenu(page *rod.Page, log *logger.Logger) []string {
+	labels := []string{}
+
+	moreBtn, err := page.Timeout(3 * time.Second).Element("[aria-label='More actions']")
+	if err != nil || !utils.IsElementVisible(moreBtn) {
+		return labels
+	}
+
+	log.Printf("Checking more actions menu...")
+	if err := utils.HumanClick(page, moreBtn); err != nil {
+		return labels
+	}
+	utils.RandomSleep(500, 1000)
+
+	if obj, err := page.Eval(readMenuJS); err == nil {
+		obj.Value.Unmarshal(&labels)
+	}
+	page.Keyboard.Press('\x1b')
+	utils.RandomSleep(300, 600)
+	return labels
+}
+
+func hasLabel(labels []string, match func(string) bool) bool {
+	for _, l := range labels {
+		if match(l) {
+			return true
+		}
+	}
+	return false
+}
+
+// "Connect", or the aria-label "Invite Jane Doe to connect"
+func isConnectLabel(l string) bool {
+	return l == "connect" || l == "add" || strings.HasSuffix(l, "to connect")
+}
+
+// "Pending", or the aria-label "Pending, click to withdraw invitation sent to Jane Doe"
+func isPendingLabel(l string) bool {
+	return l == "pending" || strings.HasPrefix(l, "pending,")
+}
+
+// "Follow", "Following" or the aria-label "Follow Jane Doe"
+func isFollowLabel(l string) bool {
+	return l == "follow" || l == "following" || strings.HasPrefix(l, "follow ") || strings.HasPrefix(l, "following ")
+}
+
+func isRemoveConnectionLabel(l string) bool {
+	return strings.HasPrefix(l, "remove connection") || strings.HasPrefix(l, "remove your connection")
+}
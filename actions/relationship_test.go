@@ -0,0 +1,93 @@
+package actions
+
+import "testing"
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		s    ProfileSignals
+		want RelationshipState
+	}{
+		{
+			name: "first degree badge",
+			s:    ProfileSignals{Degree: "1st", Buttons: []string{"message", "more"}},
+			want: RelationshipFirstDegree,
+		},
+		{
+			name: "remove connection in more menu",
+			s:    ProfileSignals{Buttons: []string{"message"}, More: []string{"remove connection"}},
+			want: RelationshipFirstDegree,
+		},
+		{
+			name: "second degree with message is not a connection",
+			s: ProfileSignals{
+				Degree:  "2nd",
+				Buttons: []string{"message", "invite jane doe to connect", "connect"},
+			},
+			want: RelationshipConnectable,
+		},
+		{
+			name: "second degree with inmail only",
+			s:    ProfileSignals{Degree: "2nd", Buttons: []string{"message", "more"}, More: []string{"save to pdf"}},
+			want: RelationshipRestricted,
+		},
+		{
+			name: "second degree with message, menu not read yet",
+			s:    ProfileSignals{Degree: "2nd", Buttons: []string{"message", "more"}},
+			want: RelationshipUnknown,
+		},
+		{
+			name: "connect only in more menu",
+			s: ProfileSignals{
+				Degree:  "2nd",
+				Buttons: []string{"follow", "message", "more"},
+				More:    []string{"send profile in a message", "invite jane doe to connect", "connect"},
+			},
+			want: RelationshipConnectableViaMore,
+		},
+		{
+			name: "follow only",
+			s: ProfileSignals{
+				Degree:  "3rd+",
+				Buttons: []string{"follow", "follow jane doe", "more"},
+				More:    []string{"save to pdf", "report / block"},
+			},
+			want: RelationshipFollowOnly,
+		},
+		{
+			name: "pending on the top card",
+			s: ProfileSignals{
+				Degree:  "2nd",
+				Buttons: []string{"pending", "pending, click to withdraw invitation sent to jane doe", "message"},
+			},
+			want: RelationshipPending,
+		},
+		{
+			name: "pending in more menu",
+			s:    ProfileSignals{Degree: "3rd", Buttons: []string{"follow"}, More: []string{"pending"}},
+			want: RelationshipPending,
+		},
+		{
+			name: "unavailable profile",
+			s:    ProfileSignals{Buttons: []string{"connect"}, Unavailable: true},
+			want: RelationshipRestricted,
+		},
+		{
+			name: "out of network without actions",
+			s:    ProfileSignals{Degree: "out of network", Buttons: []string{"more"}, More: []string{}},
+			want: RelationshipOutOfNetwork,
+		},
+		{
+			name: "no badge and no actions",
+			s:    ProfileSignals{More: []string{}},
+			want: RelationshipUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.s); got != tt.want {
+				t.Errorf("Classify() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
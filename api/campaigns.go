@@ -0,0 +1,153 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/meetm/linkedin-automation-go/pkg/campaign"
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/workflow"
+)
+
+type campaignResponse struct {
+	campaign.Campaign
+	Stats campaign.Stats `json:"stats"`
+}
+
+// GET lists campaigns, POST creates one
+func (s *Server) handleCampaigns(w http.ResponseWriter, r *http.Request) {
+	cors(w, "GET, POST")
+
+	switch r.Method {
+	case "OPTIONS":
+		return
+
+	case "GET":
+		list, err := campaign.List()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp := make([]campaignResponse, 0, len(list))
+		for _, c := range list {
+			stats, _ := campaign.StatsFor(c.ID)
+			resp = append(resp, campaignResponse{c, stats})
+		}
+		writeJSON(w, http.StatusOK, resp)
+
+	case "POST":
+		var c campaign.Campaign
+		if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := campaign.Create(&c); err != nil {
+			writeCampaignError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, c)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// GET, PUT or DELETE a single campaign
+func (s *Server) handleCampaign(w http.ResponseWriter, r *http.Request) {
+	cors(w, "GET, PUT, DELETE")
+	id := r.PathValue("id")
+
+	switch r.Method {
+	case "OPTIONS":
+		return
+
+	case "GET":
+		c, err := campaign.Get(id)
+		if err != nil {
+			writeCampaignError(w, err)
+			return
+		}
+		stats, err := campaign.StatsFor(id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, campaignResponse{*c, stats})
+
+	case "PUT":
+		var c campaign.Campaign
+		if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		c.ID = id
+		if err := campaign.Update(&c); err != nil {
+			writeCampaignError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, c)
+
+	case "DELETE":
+		if err := campaign.Delete(id); err != nil {
+			writeCampaignError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// POST starts a run of the campaign, GET lists its past runs
+func (s *Server) handleCampaignRun(w http.ResponseWriter, r *http.Request) {
+	cors(w, "GET, POST")
+	id := r.PathValue("id")
+
+	switch r.Method {
+	case "OPTIONS":
+		return
+
+	case "GET":
+		runs, err := history.List()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		matching := make([]history.Record, 0)
+		for _, rec := range runs {
+			if rec.CampaignID == id {
+				matching = append(matching, rec)
+			}
+		}
+		writeJSON(w, http.StatusOK, matching)
+
+	case "POST":
+		c, err := campaign.Get(id)
+		if err != nil {
+			writeCampaignError(w, err)
+			return
+		}
+
+		cfg := c.Config()
+		cfg.RunID = history.NewID()
+		s.background(func() { workflow.Run(cfg, s.Log) })
+
+		writeJSON(w, http.StatusOK, map[string]string{"status": "started", "runId": cfg.RunID})
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func writeCampaignError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, campaign.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, campaign.ErrExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
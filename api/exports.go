@@ -0,0 +1,51 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/export"
+)
+
+// GET /api/exports/{kind}?format=csv|jsonl|xlsx&from=&to=&outcome=&campaignId=
+func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
+	cors(w, "GET")
+
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	format, err := export.ParseFormat(q.Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filter := export.Filter{Outcome: q.Get("outcome"), CampaignID: q.Get("campaignId")}
+	if filter.From, err = export.ParseDate(q.Get("from"), false); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if filter.To, err = export.ParseDate(q.Get("to"), true); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	kind := r.PathValue("kind")
+	table, err := export.Build(kind, filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	filename := fmt.Sprintf("linkedin-%s-%s.%s", kind, time.Now().Format("20060102"), format)
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	export.Write(w, format, table)
+}
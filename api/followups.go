@@ -0,0 +1,106 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/meetm/linkedin-automation-go/pkg/followup"
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/review"
+)
+
+// handleFollowups starts sending due follow-up messages in the background.
+// Query parameters: campaignId, dryRun, headless.
+func (s *Server) handleFollowups(w http.ResponseWriter, r *http.Request) {
+	cors(w, "POST")
+
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	opts := followup.Options{RunID: history.NewID(), CampaignID: q.Get("campaignId")}
+	opts.DryRun, _ = strconv.ParseBool(q.Get("dryRun"))
+	opts.Headless, _ = strconv.ParseBool(q.Get("headless"))
+
+	s.background(func() { followup.Run(opts, s.Log) })
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "started", "runId": opts.RunID})
+}
+
+// GET lists the review queue, filtered by the kind and status query parameters
+func (s *Server) handleReview(w http.ResponseWriter, r *http.Request) {
+	cors(w, "GET")
+
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	items, err := review.List(q.Get("kind"), review.Status(q.Get("status")))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if items == nil {
+		items = []review.Item{}
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+// POST approves or rejects a queued item. Approval takes an optional
+// {"text": "..."} body replacing the message.
+func (s *Server) handleReviewDecision(w http.ResponseWriter, r *http.Request) {
+	cors(w, "POST")
+
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := r.PathValue("id")
+	var it review.Item
+	var err error
+	switch r.PathValue("decision") {
+	case "approve":
+		var body struct {
+			Text string `json:"text"`
+		}
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		it, err = review.Approve(id, body.Text)
+	case "reject":
+		it, err = review.Reject(id)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case errors.Is(err, review.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, review.ErrDecided):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		writeJSON(w, http.StatusOK, it)
+	}
+}
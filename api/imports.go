@@ -0,0 +1,100 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/meetm/linkedin-automation-go/pkg/campaign"
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/imports"
+	"github.com/meetm/linkedin-automation-go/pkg/workflow"
+)
+
+const maxImportSize = 5 << 20
+
+// handleImport accepts a CSV or JSONL list of profiles, either as a multipart
+// "file" field or as the raw request body, and starts a run over them.
+// Query parameters: campaignId, message, limit, dryRun, headless.
+func (s *Server) handleImport(w http.ResponseWriter, r *http.Request) {
+	cors(w, "POST")
+
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	// anything but a multipart form is the file itself, including curl
+	// --data-binary's default application/x-www-form-urlencoded
+	var data []byte
+	var name string
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "multipart upload needs a \"file\" field: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		name = header.Filename
+		data, err = io.ReadAll(file)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	} else {
+		var err error
+		data, err = io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if mediaType == "application/x-ndjson" {
+			name = "upload.jsonl"
+		}
+	}
+
+	q := r.URL.Query()
+	cfg := workflow.Config{}
+	if id := q.Get("campaignId"); id != "" {
+		c, err := campaign.Get(id)
+		if err != nil {
+			writeCampaignError(w, err)
+			return
+		}
+		cfg = c.Config()
+	}
+	if msg := q.Get("message"); msg != "" {
+		cfg.ConnectMessage = msg
+	}
+	if limit, err := strconv.Atoi(q.Get("limit")); err == nil {
+		cfg.Limit = limit
+	}
+	cfg.DryRun, _ = strconv.ParseBool(q.Get("dryRun"))
+	if headless, err := strconv.ParseBool(q.Get("headless")); err == nil {
+		cfg.Headless = headless
+	}
+
+	targets, report, err := imports.Parse(bytes.NewReader(data), imports.DetectFormat(name, data))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(targets) == 0 {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "nothing to import", "report": report})
+		return
+	}
+
+	cfg.Targets = targets
+	cfg.RunID = history.NewID()
+	s.background(func() { workflow.Run(cfg, s.Log) })
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"status": "started", "runId": cfg.RunID, "report": report})
+}
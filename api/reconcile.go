@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/campaign"
+	"github.com/meetm/linkedin-automation-go/pkg/reconcile"
+)
+
+// handleReconcile starts a reconcile of open invitations in the background.
+// Query parameters: campaignId, ignoredAfterDays, maxVisits, headless.
+func (s *Server) handleReconcile(w http.ResponseWriter, r *http.Request) {
+	cors(w, "POST")
+
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	opts := reconcile.Options{CampaignID: q.Get("campaignId")}
+	if days, err := strconv.Atoi(q.Get("ignoredAfterDays")); err == nil {
+		opts.IgnoredAfter = time.Duration(days) * 24 * time.Hour
+	}
+	if visits, err := strconv.Atoi(q.Get("maxVisits")); err == nil {
+		opts.MaxVisits = visits
+	}
+	opts.Headless, _ = strconv.ParseBool(q.Get("headless"))
+
+	s.background(func() { reconcile.Run(opts, s.Log) })
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "started"})
+}
+
+// handleTemplateStats returns acceptance rates per note template
+func (s *Server) handleTemplateStats(w http.ResponseWriter, r *http.Request) {
+	cors(w, "GET")
+
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	stats, err := campaign.TemplateStatsFor(r.URL.Query().Get("campaignId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if stats == nil {
+		stats = []campaign.TemplateStats{}
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/meetm/linkedin-automation-go/auth"
+	"github.com/meetm/linkedin-automation-go/pkg/handoff"
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/workflow"
+)
+
+// POST continues an interrupted or failed run from its last checkpoint
+func (s *Server) handleResumeRun(w http.ResponseWriter, r *http.Request) {
+	cors(w, "POST")
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := r.PathValue("id")
+	if _, _, err := workflow.Resumable(id); err != nil {
+		writeRunError(w, err)
+		return
+	}
+
+	s.background(func() { workflow.Resume(id, s.Log) })
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "resumed", "runId": id})
+}
+
+// GET lists the runs paused until someone solves a security checkpoint
+func (s *Server) handleHandoffs(w http.ResponseWriter, r *http.Request) {
+	cors(w, "GET")
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, http.StatusOK, handoff.Pending())
+}
+
+// POST continues a run paused at a security checkpoint once it is solved,
+// or aborts it. Continuing two-step verification takes {"code": "..."}.
+func (s *Server) handleHandoffDecision(w http.ResponseWriter, r *http.Request) {
+	cors(w, "POST")
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	decision := handoff.Decision(r.PathValue("decision"))
+	if decision != handoff.Continue && decision != handoff.Abort {
+		http.NotFound(w, r)
+		return
+	}
+
+	answer := handoff.Answer{Decision: decision}
+	if r.ContentLength != 0 {
+		var body struct {
+			Code string `json:"code"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		answer.Code = strings.TrimSpace(body.Code)
+	}
+
+	id := r.PathValue("id")
+	switch err := handoff.Resolve(id, answer); {
+	case errors.Is(err, handoff.ErrNoCode):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": string(decision), "runId": id})
+}
+
+// GET returns the last known state of the LinkedIn session, including
+// two-step verification
+func (s *Server) handleSession(w http.ResponseWriter, r *http.Request) {
+	cors(w, "GET")
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	st, err := auth.Status()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, st)
+}
+
+func writeRunError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, history.ErrRunNotFound), errors.Is(err, workflow.ErrNoCheckpoint):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, workflow.ErrRunFinished), errors.Is(err, workflow.ErrRunActive):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -1,80 +1,150 @@
-package api
-
-import (
-	"encoding/json"
-	"fmt"
-	"net/http"
-
-	"github.com/meetm/linkedin-automation-go/pkg/logger"
-	"github.com/meetm/linkedin-automation-go/pkg/workflow"
-)
-
-type Server struct {
-	Log *logger.Logger
-}
-
-func NewServer(log *logger.Logger) *Server {
-	return &Server{Log: log}
-}
-
-func (s *Server) Start() {
-	http.HandleFunc("/api/start", s.handleStart)
-	http.HandleFunc("/api/events", s.handleEvents)
-
-	fmt.Println("Server started on :8080")
-	if err := http.ListenAndServe(":8080", nil); err != nil {
-		fmt.Printf("Server failed: %v\n", err)
-	}
-}
-
-func (s *Server) handleStart(w http.ResponseWriter, r *http.Request) {
-	// CORS
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-
-	if r.Method == "OPTIONS" {
-		return
-	}
-
-	if r.Method != "POST" {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	var cfg workflow.Config
-	if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	// Run workflow in a goroutine so request returns immediately
-	go workflow.Run(cfg, s.Log)
-
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{"status": "started"})
-}
-
-func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
-	// CORS
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Content-Type", "text/event-stream")
-	w.Header().Set("Cache-Control", "no-cache")
-	w.Header().Set("Connection", "keep-alive")
-
-	ch := s.Log.Subscribe()
-	defer s.Log.Unsubscribe(ch)
-
-	// Listen for client disconnect
-	notify := r.Context().Done()
-
-	for {
-		select {
-		case msg := <-ch:
-			fmt.Fprintf(w, "data: %s\n\n", msg)
-			w.(http.Flusher).Flush()
-		case <-notify:
-			return
-		}
-	}
-}
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
+
+	"github.com/meetm/linkedin-automation-go/pkg/campaign"
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/workflow"
+)
+
+type Server struct {
+	Log *logger.Logger
+}
+
+func NewServer(log *logger.Logger) *Server {
+	return &Server{Log: log}
+}
+
+// background runs a job off the request goroutine. Jobs recover their own
+// panics into a failed run; this only keeps anything they miss from taking
+// the server down.
+func (s *Server) background(job func()) {
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				// the stack only goes to the console, log lines are single SSE events
+				s.Log.Printf("Background job panicked: %v", v)
+				fmt.Fprintf(os.Stderr, "%s\n", debug.Stack())
+			}
+		}()
+		job()
+	}()
+}
+
+func (s *Server) Start(addr string) error {
+	http.HandleFunc("/api/start", s.handleStart)
+	http.HandleFunc("/api/events", s.handleEvents)
+	http.HandleFunc("/api/imports", s.handleImport)
+	http.HandleFunc("/api/exports/{kind}", s.handleExport)
+	http.HandleFunc("/api/campaigns", s.handleCampaigns)
+	http.HandleFunc("/api/campaigns/{id}", s.handleCampaign)
+	http.HandleFunc("/api/campaigns/{id}/runs", s.handleCampaignRun)
+	http.HandleFunc("/api/runs/{id}/resume", s.handleResumeRun)
+	http.HandleFunc("/api/runs/{id}/{decision}", s.handleHandoffDecision)
+	http.HandleFunc("/api/handoffs", s.handleHandoffs)
+	http.HandleFunc("/api/session", s.handleSession)
+	http.HandleFunc("/api/reconcile", s.handleReconcile)
+	http.HandleFunc("/api/withdraw", s.handleWithdraw)
+	http.HandleFunc("/api/followups", s.handleFollowups)
+	http.HandleFunc("/api/review", s.handleReview)
+	http.HandleFunc("/api/review/{id}/{decision}", s.handleReviewDecision)
+	http.HandleFunc("/api/stats/templates", s.handleTemplateStats)
+
+	fmt.Printf("Server started on %s\n", addr)
+	if err := http.ListenAndServe(addr, nil); err != nil {
+		fmt.Printf("Server failed: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+func (s *Server) handleStart(w http.ResponseWriter, r *http.Request) {
+	// CORS
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var cfg workflow.Config
+	if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := cfg.Criteria().Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if cfg.CampaignID != "" {
+		if _, err := campaign.Get(cfg.CampaignID); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	cfg.RunID = history.NewID()
+
+	// Run workflow in a goroutine so request returns immediately
+	s.background(func() { workflow.Run(cfg, s.Log) })
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"status": "started", "runId": cfg.RunID})
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	// CORS
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := s.Log.Subscribe()
+	defer s.Log.Unsubscribe(ch)
+
+	// Listen for client disconnect
+	notify := r.Context().Done()
+
+	for {
+		select {
+		case msg := <-ch:
+			if msg.Event != "" {
+				fmt.Fprintf(w, "event: %s\n", msg.Event)
+			}
+			// a data line per line, so multi-line messages keep the framing
+			for _, line := range strings.Split(msg.Data, "\n") {
+				fmt.Fprintf(w, "data: %s\n", line)
+			}
+			fmt.Fprint(w, "\n")
+			w.(http.Flusher).Flush()
+		case <-notify:
+			return
+		}
+	}
+}
+
+func cors(w http.ResponseWriter, methods string) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", methods+", OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/withdraw"
+)
+
+// handleWithdraw starts withdrawing stale invitations in the background.
+// Query parameters: olderThanDays, campaignId, limit, dailyCap, dryRun, headless.
+func (s *Server) handleWithdraw(w http.ResponseWriter, r *http.Request) {
+	cors(w, "POST")
+
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	opts := withdraw.Options{RunID: history.NewID(), CampaignID: q.Get("campaignId")}
+	if days, err := strconv.Atoi(q.Get("olderThanDays")); err == nil {
+		opts.OlderThan = time.Duration(days) * 24 * time.Hour
+	}
+	if limit, err := strconv.Atoi(q.Get("limit")); err == nil {
+		opts.Limit = limit
+	}
+	if limit, err := strconv.Atoi(q.Get("dailyCap")); err == nil {
+		opts.DailyCap = limit
+	}
+	opts.DryRun, _ = strconv.ParseBool(q.Get("dryRun"))
+	opts.Headless, _ = strconv.ParseBool(q.Get("headless"))
+
+	s.background(func() { withdraw.Run(opts, s.Log) })
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "started", "runId": opts.RunID})
+}
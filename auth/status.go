@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/storage"
+)
+
+// TwoFactorState is where the last login got to with two-factor verification
+type TwoFactorState string
+
+const (
+	TwoFactorNone     TwoFactorState = ""              // not asked for
+	TwoFactorRequired TwoFactorState = "required"      // LinkedIn asked for a code
+	TwoFactorAwaiting TwoFactorState = "awaiting-code" // waiting for someone to enter it
+	TwoFactorPassed   TwoFactorState = "passed"
+	TwoFactorFailed   TwoFactorState = "failed"
+)
+
+// SessionStatus is the last known state of the LinkedIn session
+type SessionStatus struct {
+	SignedIn        bool           `json:"signedIn"`
+	LastLogin       time.Time      `json:"lastLogin,omitzero"`
+	TwoFactor       TwoFactorState `json:"twoFactor,omitempty"`
+	TwoFactorMethod string         `json:"twoFactorMethod,omitempty"` // "totp" or "prompt"
+	Error           string         `json:"error,omitempty"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+}
+
+const statusFile = "session.json"
+
+var statusMu sync.Mutex
+
+// Status returns the saved session status
+func Status() (SessionStatus, error) {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+
+	var st SessionStatus
+	if err := storage.ReadJSON(storage.Path(statusFile), &st); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return st, err
+	}
+	return st, nil
+}
+
+// updateStatus applies fn to the saved session status
+func updateStatus(fn func(*SessionStatus)) {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+
+	var st SessionStatus
+	storage.ReadJSON(storage.Path(statusFile), &st)
+	fn(&st)
+	st.UpdatedAt = time.Now()
+	storage.WriteJSON(storage.Path(statusFile), st)
+}
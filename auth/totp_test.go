@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+// the SHA-1 test vectors of RFC 6238, Appendix B, cut to 6 digits. The key
+// is the ASCII string "12345678901234567890".
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTP(t *testing.T) {
+	tests := []struct {
+		secret string
+		unix   int64
+		want   string
+	}{
+		{rfcSecret, 59, "287082"},
+		{rfcSecret, 1111111109, "081804"},
+		{rfcSecret, 1111111111, "050471"},
+		{rfcSecret, 1234567890, "005924"},
+		{rfcSecret, 2000000000, "279037"},
+		{rfcSecret, 20000000000, "353130"},
+
+		// same step as 59
+		{rfcSecret, 30, "287082"},
+		// lowercase and grouped as authenticator setup pages show it
+		{"gezd gnbv gy3t qojq gezd gnbv gy3t qojq", 59, "287082"},
+		{"  " + rfcSecret + "\n", 1234567890, "005924"},
+		// "1234567890123456789", which needs padding
+		{"GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOI=", 59, "155704"},
+		{"gezdgnbvgy3tqojqgezdgnbvgy3tqoi", 59, "155704"},
+	}
+	for _, tt := range tests {
+		got, err := TOTP(tt.secret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Errorf("TOTP(%q, %d): %v", tt.secret, tt.unix, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("TOTP(%q, %d) = %s, want %s", tt.secret, tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestTOTPInvalidSecret(t *testing.T) {
+	for _, secret := range []string{"not base32!", "GEZDGNBV1", "GEZ=DGNBV"} {
+		if code, err := TOTP(secret, time.Unix(59, 0)); err == nil {
+			t.Errorf("TOTP(%q) = %s, want an error", secret, code)
+		}
+	}
+}
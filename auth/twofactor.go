@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/vault"
+	"github.com/meetm/linkedin-automation-go/utils"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/input"
+)
+
+var (
+	ErrTwoFactorRequired = errors.New("login needs a two-step verification code")
+	ErrTwoFactorFailed   = errors.New("two-step verification code was not accepted")
+)
+
+// CodePrompt asks a person for a two-step verification code, e.g. one sent
+// by SMS. It is used when the vault holds no TOTP secret or its code was
+// not accepted.
+type CodePrompt func() (string, error)
+
+// the code field of LinkedIn's two-step verification page
+const pinSelector = "input[name='pin'], input#input__phone_verification_pin, input#input__email_verification_pin"
+
+func hasTwoFactorPrompt(page *rod.Page) bool {
+	has, _, _ := page.Has(pinSelector)
+	return has
+}
+
+// codeSource is one way of getting a verification code
+type codeSource struct {
+	method string // recorded in the session status
+	code   func() (string, error)
+}
+
+// twoFactor completes two-step verification, first with a code from the
+// TOTP secret in the vault, then with one from prompt
+func twoFactor(page *rod.Page, prompt CodePrompt, log *logger.Logger) error {
+	log.Printf("Two-step verification required")
+	updateStatus(func(st *SessionStatus) { st.TwoFactor = TwoFactorRequired })
+
+	var sources []codeSource
+	if secret, err := vault.Get(vault.TOTPSecret); err == nil {
+		sources = append(sources, codeSource{"totp", func() (string, error) {
+			return TOTP(secret, time.Now())
+		}})
+	}
+	if prompt != nil {
+		sources = append(sources, codeSource{"prompt", func() (string, error) {
+			updateStatus(func(st *SessionStatus) { st.TwoFactor = TwoFactorAwaiting })
+			return prompt()
+		}})
+	}
+
+	err := fmt.Errorf("%w: no TOTP secret in the vault and no way to ask for a code", ErrTwoFactorRequired)
+	for _, src := range sources {
+		code, codeErr := src.code()
+		if codeErr != nil {
+			log.Printf("No verification code from %s: %v", src.method, codeErr)
+			err = fmt.Errorf("%w: %v", ErrTwoFactorRequired, codeErr)
+			continue
+		}
+
+		log.Printf("Entering verification code (%s)...", src.method)
+		if err := submitCode(page, code, log); err != nil {
+			return err
+		}
+		err = validateLogin(page, log)
+		if !errors.Is(err, ErrTwoFactorRequired) {
+			if err == nil {
+				updateStatus(func(st *SessionStatus) {
+					st.TwoFactor, st.TwoFactorMethod = TwoFactorPassed, src.method
+				})
+			}
+			return err
+		}
+		log.Printf("Verification code was not accepted")
+		err = ErrTwoFactorFailed
+	}
+
+	updateStatus(func(st *SessionStatus) { st.TwoFactor = TwoFactorFailed })
+	return err
+}
+
+func submitCode(page *rod.Page, code string, log *logger.Logger) error {
+	pin, err := utils.WaitForElement(page, pinSelector, 10*time.Second)
+	if err != nil {
+		return errors.New("could not find verification code field")
+	}
+	if err := pin.SelectAllText(); err != nil {
+		return err
+	}
+	if err := utils.HumanType(page, pin, code); err != nil {
+		return err
+	}
+	utils.RandomSleep(500, 1000)
+
+	if btn, err := page.Timeout(2 * time.Second).Element("#two-step-submit-button, form button[type='submit']"); err == nil {
+		if err := utils.HumanClick(page, btn); err != nil {
+			return err
+		}
+	} else {
+		page.Keyboard.Press(input.Enter)
+	}
+
+	utils.LongRandomSleep(3, 5)
+	return utils.WaitStable(page, time.Second, log)
+}
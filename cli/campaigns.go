@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/meetm/linkedin-automation-go/pkg/campaign"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+)
+
+func campaignsCmd(args []string, log *logger.Logger) int {
+	fs := newFlagSet("campaigns")
+	if code, ok := parse(fs, args); !ok {
+		return code
+	}
+
+	list, err := campaign.List()
+	if err != nil {
+		return fail("%v", err)
+	}
+	if len(list) == 0 {
+		fmt.Println("No campaigns yet, create one with POST /api/campaigns")
+		return ExitOK
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tSEARCH\tPER RUN\tDAILY\tSCHEDULED\tRUNS\tSENT\tACCEPTED")
+	for _, c := range list {
+		stats, err := campaign.StatsFor(c.ID)
+		if err != nil {
+			return fail("%v", err)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%t\t%d\t%d\t%.0f%%\n",
+			c.ID, c.Name, c.Search, c.Limits.PerRun, c.Limits.Daily,
+			c.Schedule != nil && c.Schedule.Enabled,
+			stats.Runs, stats.Sent, stats.AcceptanceRate*100)
+	}
+	tw.Flush()
+	return ExitOK
+}
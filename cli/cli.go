@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/meetm/linkedin-automation-go/pkg/handoff"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+)
+
+// exit codes returned by Run
+const (
+	ExitOK      = 0
+	ExitFailure = 1
+	ExitUsage   = 2
+	ExitPartial = 3
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, log *logger.Logger) int
+}
+
+var commands = []command{
+	{"serve", "Start the HTTP API server", serveCmd},
+	{"run", "Run a search-and-connect workflow", runCmd},
+	{"resume", "Continue an interrupted run from its checkpoint", resumeCmd},
+	{"import", "Contact profiles listed in a CSV or JSONL file", importCmd},
+	{"campaigns", "List campaigns with their totals", campaignsCmd},
+	{"schedule", "Run scheduled campaigns in the foreground", scheduleCmd},
+	{"reconcile", "Check which invitations were accepted", reconcileCmd},
+	{"withdraw", "Withdraw stale pending invitations", withdrawCmd},
+	{"followup", "Send due follow-up messages to accepted contacts", followupCmd},
+	{"review", "List, approve or reject queued messages", reviewCmd},
+	{"login", "Sign in and save the session cookies", loginCmd},
+	{"vault", "Store the TOTP secret used for two-step verification", vaultCmd},
+	{"history", "List previous runs", historyCmd},
+	{"export", "Export run results, contacts or runs to CSV, JSONL or XLSX", exportCmd},
+}
+
+// Run dispatches args to a subcommand and returns the process exit code.
+// With no arguments the API server is started.
+func Run(args []string, log *logger.Logger) int {
+	if len(args) == 0 {
+		return serveCmd(nil, log)
+	}
+
+	name := args[0]
+	if name == "help" || name == "-h" || name == "--help" {
+		usage(os.Stdout)
+		return ExitOK
+	}
+
+	if name != "serve" {
+		// nobody is watching the API, so checkpoints are handed off here
+		handoff.OnRequest = promptHandoff
+	}
+
+	for _, c := range commands {
+		if c.name == name {
+			return c.run(args[1:], log)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+	usage(os.Stderr)
+	return ExitUsage
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: linkedin-automation <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'linkedin-automation <command> -h' for command flags.")
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+// parse returns the exit code to use when flag parsing does not succeed
+func parse(fs *flag.FlagSet, args []string) (int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK, false
+		}
+		return ExitUsage, false
+	}
+	return ExitOK, true
+}
+
+func fail(format string, v ...interface{}) int {
+	fmt.Fprintf(os.Stderr, "error: "+format+"\n", v...)
+	return ExitFailure
+}
+
+// splitList splits a comma-separated flag value, dropping empty items
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
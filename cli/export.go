@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/meetm/linkedin-automation-go/pkg/export"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+)
+
+func exportCmd(args []string, log *logger.Logger) int {
+	fs := newFlagSet("export")
+	kind := fs.String("what", export.KindResults, "what to export: results, contacts or runs")
+	format := fs.String("format", "", "csv, jsonl or xlsx (default from --out extension, else csv)")
+	out := fs.String("out", "", "output file (default stdout)")
+	from := fs.String("from", "", "only rows on or after this date (YYYY-MM-DD)")
+	to := fs.String("to", "", "only rows on or before this date (YYYY-MM-DD)")
+	outcome := fs.String("outcome", "", "only this outcome (results), ledger status (contacts) or run status (runs)")
+	campaignID := fs.String("campaign", "", "only this campaign")
+	if code, ok := parse(fs, args); !ok {
+		return code
+	}
+
+	if *format == "" && *out != "" {
+		*format = strings.TrimPrefix(filepath.Ext(*out), ".")
+	}
+	f, err := export.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintf(fs.Output(), "export: %v\n", err)
+		return ExitUsage
+	}
+	if f == export.FormatXLSX && *out == "" {
+		fmt.Fprintln(fs.Output(), "export: xlsx needs --out")
+		return ExitUsage
+	}
+
+	filter := export.Filter{Outcome: *outcome, CampaignID: *campaignID}
+	if filter.From, err = export.ParseDate(*from, false); err != nil {
+		fmt.Fprintf(fs.Output(), "export: %v\n", err)
+		return ExitUsage
+	}
+	if filter.To, err = export.ParseDate(*to, true); err != nil {
+		fmt.Fprintf(fs.Output(), "export: %v\n", err)
+		return ExitUsage
+	}
+
+	table, err := export.Build(*kind, filter)
+	if err != nil {
+		return fail("%v", err)
+	}
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return fail("%v", err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if err := export.Write(w, f, table); err != nil {
+		return fail("%v", err)
+	}
+	if *out != "" {
+		fmt.Printf("Exported %d rows to %s\n", len(table.Rows), *out)
+	}
+	return ExitOK
+}
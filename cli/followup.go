@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/meetm/linkedin-automation-go/pkg/followup"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/review"
+)
+
+func followupCmd(args []string, log *logger.Logger) int {
+	fs := newFlagSet("followup")
+	campaignID := fs.String("campaign", "", "only this campaign's sequence")
+	headless := fs.Bool("headless", false, "run browser headless")
+	dryRun := fs.Bool("dry-run", false, "list due messages without sending them")
+	if code, ok := parse(fs, args); !ok {
+		return code
+	}
+
+	rec, err := followup.Run(followup.Options{
+		CampaignID: *campaignID,
+		DryRun:     *dryRun,
+		Headless:   *headless,
+	}, log)
+	if rec != nil {
+		printRun(os.Stdout, rec)
+	}
+	if err != nil {
+		return fail("%v", err)
+	}
+	if rec.Failed > 0 {
+		return ExitPartial
+	}
+	return ExitOK
+}
+
+func reviewCmd(args []string, log *logger.Logger) int {
+	fs := newFlagSet("review")
+	approve := fs.String("approve", "", "approve the item with this ID")
+	reject := fs.String("reject", "", "reject the item with this ID")
+	text := fs.String("text", "", "replace the message text when approving")
+	all := fs.Bool("all", false, "list decided items too")
+	if code, ok := parse(fs, args); !ok {
+		return code
+	}
+
+	switch {
+	case *approve != "":
+		it, err := review.Approve(*approve, *text)
+		if err != nil {
+			return fail("%v", err)
+		}
+		fmt.Printf("Approved %s for %s\n", it.ID, it.ProfileURL)
+		return ExitOK
+	case *reject != "":
+		it, err := review.Reject(*reject)
+		if err != nil {
+			return fail("%v", err)
+		}
+		fmt.Printf("Rejected %s for %s\n", it.ID, it.ProfileURL)
+		return ExitOK
+	}
+
+	status := review.StatusPending
+	if *all {
+		status = ""
+	}
+	items, err := review.List("", status)
+	if err != nil {
+		return fail("%v", err)
+	}
+	if len(items) == 0 {
+		fmt.Println("Nothing to review")
+		return ExitOK
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tKIND\tSTATUS\tCAMPAIGN\tPROFILE\tDETAILS")
+	for _, it := range items {
+		details := it.Name
+		if it.Kind == review.KindMessage {
+			details = templateLabel(it.Text)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			it.ID, it.Kind, it.Status, it.CampaignID, it.ProfileURL, details)
+	}
+	tw.Flush()
+	return ExitOK
+}
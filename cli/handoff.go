@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/meetm/linkedin-automation-go/pkg/handoff"
+)
+
+var (
+	stdinOnce  sync.Once
+	stdinLines chan string // closed at the end of input
+)
+
+// readStdin starts the one reader of standard input, so prompts never race
+// for a line. Lines typed while no prompt is waiting are dropped.
+func readStdin() <-chan string {
+	stdinOnce.Do(func() {
+		stdinLines = make(chan string)
+		go func() {
+			defer close(stdinLines)
+			in := bufio.NewScanner(os.Stdin)
+			for in.Scan() {
+				select {
+				case stdinLines <- in.Text():
+				default:
+				}
+			}
+		}()
+	})
+	return stdinLines
+}
+
+// promptHandoff asks on the terminal for a two-step verification code, or
+// whether a run paused at a security checkpoint should continue once it has
+// been solved in the browser. The prompt ends when the request is answered,
+// here or through the API.
+func promptHandoff(req handoff.Request) {
+	if req.Kind == handoff.KindTwoFactor {
+		fmt.Fprintf(os.Stderr, "\n%s (or type abort): ", req.Reason)
+	} else {
+		fmt.Fprintf(os.Stderr, "\n%s: %s\nSolve it in the browser window, then press Enter to continue, or type abort: ", req.Reason, req.URL)
+	}
+	lines, done := readStdin(), handoff.Done(req.RunID)
+	go func() {
+		for {
+			var line string
+			select {
+			case <-done:
+				return
+			case l, ok := <-lines:
+				if !ok {
+					return
+				}
+				line = strings.TrimSpace(l)
+			}
+
+			answer := handoff.Answer{Decision: handoff.Continue, Code: line}
+			if strings.EqualFold(line, "abort") {
+				answer = handoff.Answer{Decision: handoff.Abort}
+			}
+			if err := handoff.Resolve(req.RunID, answer); errors.Is(err, handoff.ErrNoCode) {
+				fmt.Fprint(os.Stderr, "Enter the code, or type abort: ")
+				continue
+			} else if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			return
+		}
+	}()
+}
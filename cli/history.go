@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+)
+
+func historyCmd(args []string, log *logger.Logger) int {
+	fs := newFlagSet("history")
+	limit := fs.Int("limit", 20, "number of runs to show")
+	id := fs.String("id", "", "show per-profile results for a single run")
+	if code, ok := parse(fs, args); !ok {
+		return code
+	}
+
+	if *id != "" {
+		rec, err := history.Load(*id)
+		if err != nil {
+			return fail("%v", err)
+		}
+		printRun(os.Stdout, rec)
+		return ExitOK
+	}
+
+	records, err := history.List()
+	if err != nil {
+		return fail("%v", err)
+	}
+	if len(records) == 0 {
+		fmt.Println("No runs recorded yet")
+		return ExitOK
+	}
+	if *limit > 0 && len(records) > *limit {
+		records = records[:*limit]
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSTARTED\tSTATUS\tCAMPAIGN\tKEYWORD\tFOUND\tSENT\tSKIPPED\tFAILED")
+	for _, r := range records {
+		campaignID := r.CampaignID
+		if campaignID == "" {
+			campaignID = "-"
+		}
+		keyword := r.Keyword
+		if r.Kind != "" {
+			keyword = "(" + r.Kind + ")"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t%d\n",
+			r.ID, r.StartedAt.Local().Format("2006-01-02 15:04"), r.Status, campaignID, keyword,
+			r.ProfilesFound, r.Sent, r.Skipped, r.Failed)
+	}
+	tw.Flush()
+	return ExitOK
+}
+
+func printRun(w io.Writer, rec *history.Record) {
+	fmt.Fprintf(w, "Run %s (%s)\n", rec.ID, rec.Status)
+	if rec.CampaignID != "" {
+		fmt.Fprintf(w, "  Campaign: %s\n", rec.CampaignID)
+	}
+	if rec.Kind != "" {
+		fmt.Fprintf(w, "  Job: %s, limit %d\n", rec.Kind, rec.Limit)
+	} else {
+		fmt.Fprintf(w, "  Search: %s, limit %d\n", rec.Criteria, rec.Limit)
+	}
+	fmt.Fprintf(w, "  Started: %s\n", rec.StartedAt.Local().Format("2006-01-02 15:04:05"))
+	if !rec.FinishedAt.IsZero() {
+		fmt.Fprintf(w, "  Finished: %s\n", rec.FinishedAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	if rec.Error != "" {
+		fmt.Fprintf(w, "  Error: %s\n", rec.Error)
+	}
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "OUTCOME\tPROFILE\tREASON")
+	for _, res := range rec.Results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", res.Outcome, res.ProfileURL, res.Reason)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/meetm/linkedin-automation-go/pkg/campaign"
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/imports"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/workflow"
+)
+
+func importCmd(args []string, log *logger.Logger) int {
+	fs := newFlagSet("import")
+	file := fs.String("file", "", "CSV or JSONL file of profile URLs (required)")
+	campaignID := fs.String("campaign", "", "record the run against a campaign and use its note and caps")
+	message := fs.String("message", "", "note template, e.g. \"Hi {{firstName}}!\"")
+	limit := fs.Int("limit", 0, "max profiles to process (default all)")
+	headless := fs.Bool("headless", false, "run browser headless")
+	dryRun := fs.Bool("dry-run", false, "validate and list profiles without sending requests")
+	if code, ok := parse(fs, args); !ok {
+		return code
+	}
+
+	if *file == "" {
+		fmt.Fprintln(fs.Output(), "import: --file is required")
+		fs.Usage()
+		return ExitUsage
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		return fail("%v", err)
+	}
+
+	cfg := workflow.Config{}
+	if *campaignID != "" {
+		c, err := campaign.Get(*campaignID)
+		if err != nil {
+			return fail("%v", err)
+		}
+		cfg = c.Config()
+	}
+	if *message != "" {
+		cfg.ConnectMessage = *message
+	}
+	if *limit > 0 {
+		cfg.Limit = *limit
+	}
+	cfg.Headless = cfg.Headless || *headless
+	cfg.DryRun = *dryRun
+
+	targets, report, err := imports.Parse(bytes.NewReader(data), imports.DetectFormat(*file, data))
+	if err != nil {
+		return fail("%v", err)
+	}
+
+	fmt.Printf("Rows: %d, accepted: %d, duplicates: %d, already contacted: %d, invalid: %d\n",
+		report.Rows, report.Accepted, report.Duplicates, report.AlreadyContacted, len(report.Invalid))
+	for _, rej := range report.Invalid {
+		fmt.Printf("  row %d: %q: %s\n", rej.Row, rej.Value, rej.Reason)
+	}
+	if len(targets) == 0 {
+		fmt.Println("Nothing to import")
+		return ExitOK
+	}
+
+	cfg.Targets = targets
+	cfg.RunID = history.NewID()
+	stats, err := workflow.Run(cfg, log)
+	return summarize(cfg.RunID, stats, err)
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/campaign"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/reconcile"
+)
+
+func reconcileCmd(args []string, log *logger.Logger) int {
+	fs := newFlagSet("reconcile")
+	campaignID := fs.String("campaign", "", "only invitations sent for this campaign")
+	ignoredAfter := fs.Int("ignored-after", 21, "days after which a pending invitation counts as ignored")
+	maxVisits := fs.Int("max-visits", reconcile.DefaultMaxVisits, "max profiles to re-check individually")
+	headless := fs.Bool("headless", false, "run browser headless")
+	if code, ok := parse(fs, args); !ok {
+		return code
+	}
+
+	report, err := reconcile.Run(reconcile.Options{
+		CampaignID:   *campaignID,
+		Headless:     *headless,
+		IgnoredAfter: time.Duration(*ignoredAfter) * 24 * time.Hour,
+		MaxVisits:    *maxVisits,
+	}, log)
+	if err != nil {
+		return fail("%v", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("Checked %d invitations, %d changed\n", report.Checked, report.Changed)
+	fmt.Printf("  Accepted:   %d\n", report.Accepted)
+	fmt.Printf("  Pending:    %d\n", report.Pending)
+	fmt.Printf("  Ignored:    %d\n", report.Ignored)
+	fmt.Printf("  Withdrawn:  %d\n", report.Withdrawn)
+	fmt.Printf("  Unresolved: %d\n", report.Unresolved)
+
+	templates, err := campaign.TemplateStatsFor(*campaignID)
+	if err != nil {
+		return fail("%v", err)
+	}
+	if len(templates) > 0 {
+		fmt.Println()
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "TEMPLATE\tSENT\tACCEPTED\tRATE")
+		for _, t := range templates {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%.0f%%\n", templateLabel(t.Template), t.Sent, t.Accepted, t.AcceptanceRate*100)
+		}
+		tw.Flush()
+	}
+	return ExitOK
+}
+
+// templateLabel shortens a note template to one table cell
+func templateLabel(tmpl string) string {
+	if tmpl == "" {
+		return "(no note)"
+	}
+	runes := []rune(tmpl)
+	if len(runes) > 50 {
+		return string(runes[:47]) + "..."
+	}
+	return tmpl
+}
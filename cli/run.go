@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/actions"
+	"github.com/meetm/linkedin-automation-go/api"
+	"github.com/meetm/linkedin-automation-go/auth"
+	"github.com/meetm/linkedin-automation-go/pkg/campaign"
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/scheduler"
+	"github.com/meetm/linkedin-automation-go/pkg/workflow"
+)
+
+func serveCmd(args []string, log *logger.Logger) int {
+	fs := newFlagSet("serve")
+	addr := fs.String("addr", ":8080", "address to listen on")
+	withScheduler := fs.Bool("scheduler", true, "run scheduled campaigns while serving")
+	if code, ok := parse(fs, args); !ok {
+		return code
+	}
+
+	if *withScheduler {
+		go scheduler.New(log, campaign.ScheduleEntries).Start(context.Background(), time.Minute)
+	}
+
+	server := api.NewServer(log)
+	if err := server.Start(*addr); err != nil {
+		return ExitFailure
+	}
+	return ExitOK
+}
+
+func runCmd(args []string, log *logger.Logger) int {
+	fs := newFlagSet("run")
+	campaignID := fs.String("campaign", "", "run a saved campaign; other flags override its settings")
+	keyword := fs.String("keyword", "", "search keyword for profiles")
+	network := fs.String("network", "", "connection degrees, comma-separated: 2nd,3rd")
+	geo := fs.String("geo", "", "comma-separated geo URN IDs")
+	currentCompany := fs.String("current-company", "", "comma-separated current company IDs")
+	pastCompany := fs.String("past-company", "", "comma-separated past company IDs")
+	industry := fs.String("industry", "", "comma-separated industry IDs")
+	school := fs.String("school", "", "comma-separated school IDs")
+	title := fs.String("title", "", "current job title")
+	language := fs.String("language", "", "comma-separated profile language codes, e.g. en,de")
+	limit := fs.Int("limit", 10, "max profiles to process")
+	message := fs.String("message", "", "connection note")
+	headless := fs.Bool("headless", false, "run browser headless")
+	fromCards := fs.Bool("from-cards", false, "send invitations from search result cards when they offer Connect")
+	followOnly := fs.String("follow-only", "", "profiles without Connect: skip, follow or review")
+	notePolicy := fs.String("note-policy", "", "when the note cannot be added: fallback, require or skip")
+	dryRun := fs.Bool("dry-run", false, "search and list profiles without sending requests")
+	if code, ok := parse(fs, args); !ok {
+		return code
+	}
+
+	cfg := workflow.Config{Limit: *limit}
+	if *campaignID != "" {
+		c, err := campaign.Get(*campaignID)
+		if err != nil {
+			return fail("%v", err)
+		}
+		cfg = c.Config()
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "keyword":
+			cfg.Search.Keywords = *keyword
+		case "network":
+			cfg.Search.Network = splitList(*network)
+		case "geo":
+			cfg.Search.Geo = splitList(*geo)
+		case "current-company":
+			cfg.Search.CurrentCompany = splitList(*currentCompany)
+		case "past-company":
+			cfg.Search.PastCompany = splitList(*pastCompany)
+		case "industry":
+			cfg.Search.Industry = splitList(*industry)
+		case "school":
+			cfg.Search.School = splitList(*school)
+		case "title":
+			cfg.Search.Title = *title
+		case "language":
+			cfg.Search.ProfileLanguage = splitList(*language)
+		case "limit":
+			cfg.Limit = *limit
+		case "message":
+			cfg.ConnectMessage = *message
+		case "headless":
+			cfg.Headless = *headless
+		case "from-cards":
+			cfg.ConnectFromCards = *fromCards
+		case "follow-only":
+			cfg.FollowOnly = *followOnly
+		case "note-policy":
+			cfg.NotePolicy = actions.NotePolicy(*notePolicy)
+		}
+	})
+	cfg.RunID = history.NewID()
+	cfg.DryRun = *dryRun
+
+	if err := cfg.Criteria().Validate(); err != nil {
+		fmt.Fprintf(fs.Output(), "run: %v (use --keyword, a filter flag or --campaign)\n", err)
+		return ExitUsage
+	}
+	if cfg.Limit <= 0 {
+		fmt.Fprintln(fs.Output(), "run: --limit must be positive")
+		return ExitUsage
+	}
+	switch cfg.FollowOnly {
+	case "", workflow.FollowOnlySkip, workflow.FollowOnlyFollow, workflow.FollowOnlyReview:
+	default:
+		fmt.Fprintln(fs.Output(), "run: --follow-only must be skip, follow or review")
+		return ExitUsage
+	}
+	switch cfg.NotePolicy {
+	case "", actions.NoteFallback, actions.NoteRequire, actions.NoteSkip:
+	default:
+		fmt.Fprintln(fs.Output(), "run: --note-policy must be fallback, require or skip")
+		return ExitUsage
+	}
+
+	stats, err := workflow.Run(cfg, log)
+	return summarize(cfg.RunID, stats, err)
+}
+
+func resumeCmd(args []string, log *logger.Logger) int {
+	fs := newFlagSet("resume")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: linkedin-automation resume <run-id>")
+		fs.PrintDefaults()
+	}
+	if code, ok := parse(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return ExitUsage
+	}
+
+	runID := fs.Arg(0)
+	if _, _, err := workflow.Resumable(runID); err != nil {
+		return fail("%v", err)
+	}
+	stats, err := workflow.Resume(runID, log)
+	return summarize(runID, stats, err)
+}
+
+// summarize prints the outcome of a run and maps it to an exit code
+func summarize(runID string, stats workflow.WorkflowStats, err error) int {
+	fmt.Println()
+	fmt.Printf("Run %s\n", runID)
+	fmt.Printf("  Profiles found:     %d\n", stats.ProfilesFound)
+	for _, o := range stats.Outcomes.Sorted() {
+		fmt.Printf("  %-19s %d\n", string(o)+":", stats.Outcomes[o])
+	}
+	if rec, err := history.Load(runID); err == nil && rec.Status == history.StatusStopped {
+		fmt.Printf("  Stopped early:      %s\n", rec.StopReason)
+		fmt.Printf("  Continue with:      linkedin-automation resume %s\n", runID)
+	}
+
+	if err != nil {
+		return fail("%v", err)
+	}
+	if stats.Outcomes.Failed() > 0 {
+		return ExitPartial
+	}
+	return ExitOK
+}
+
+func loginCmd(args []string, log *logger.Logger) int {
+	fs := newFlagSet("login")
+	headless := fs.Bool("headless", false, "run browser headless")
+	if code, ok := parse(fs, args); !ok {
+		return code
+	}
+
+	if err := workflow.Login(workflow.Config{Headless: *headless}, log); err != nil {
+		return fail("login failed: %v", err)
+	}
+	fmt.Println("Login successful, session saved")
+	if st, err := auth.Status(); err == nil && st.TwoFactor != auth.TwoFactorNone {
+		fmt.Printf("Two-step verification: %s (%s)\n", st.TwoFactor, st.TwoFactorMethod)
+	}
+	return ExitOK
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/campaign"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/scheduler"
+)
+
+func scheduleCmd(args []string, log *logger.Logger) int {
+	fs := newFlagSet("schedule")
+	status := fs.Bool("status", false, "show scheduled campaigns and remaining quota, then exit")
+	now := fs.String("run-now", "", "start the named campaign immediately (still within its window and quota)")
+	if code, ok := parse(fs, args); !ok {
+		return code
+	}
+
+	entries, err := campaign.ScheduleEntries()
+	if err != nil {
+		return fail("%v", err)
+	}
+	sched := scheduler.New(log, campaign.ScheduleEntries)
+
+	switch {
+	case *status:
+		printSchedule(sched, entries)
+		return ExitOK
+
+	case *now != "":
+		for _, e := range entries {
+			if e.Name == *now {
+				if err := sched.Trigger(e, time.Now()); err != nil {
+					return fail("%v", err)
+				}
+				return ExitOK
+			}
+		}
+		return fail("no scheduled campaign named %q", *now)
+	}
+
+	if len(entries) == 0 {
+		return fail("no campaigns have a schedule")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	sched.Start(ctx, time.Minute)
+	return ExitOK
+}
+
+func printSchedule(sched *scheduler.Scheduler, entries []scheduler.Entry) {
+	if len(entries) == 0 {
+		fmt.Println("No campaigns have a schedule")
+		return
+	}
+
+	now := time.Now()
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CAMPAIGN\tENABLED\tCRON\tWINDOW\tIN WINDOW\tNEXT\tALLOWANCE\tLAST RUN")
+	for _, e := range entries {
+		st := sched.Status(e.Name)
+		loc, _ := e.Spec.Location()
+		cron, _ := scheduler.ParseCron(e.Spec.Cron)
+
+		last := "-"
+		if !st.LastRun.IsZero() {
+			last = st.LastRun.In(loc).Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(tw, "%s\t%t\t%s\t%s-%s %s\t%t\t%s\t%d\t%s\n",
+			e.Name, e.Spec.Enabled, e.Spec.Cron,
+			e.Spec.Window.Start, e.Spec.Window.End, loc,
+			e.Spec.Window.Contains(now.In(loc)),
+			cron.Next(now.In(loc)).Format("Mon 15:04"),
+			e.Spec.Allowance(&st, now), last)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/auth"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/vault"
+)
+
+func vaultCmd(args []string, log *logger.Logger) int {
+	fs := newFlagSet("vault")
+	totp := fs.String("totp-secret", "", "store the authenticator app's base32 secret; - reads it from stdin")
+	del := fs.String("delete", "", "delete the secret with this name")
+	if code, ok := parse(fs, args); !ok {
+		return code
+	}
+
+	switch {
+	case *totp != "":
+		secret := *totp
+		if secret == "-" {
+			line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+			if err != nil && line == "" {
+				return fail("reading secret: %v", err)
+			}
+			secret = strings.TrimSpace(line)
+		}
+		// a secret that cannot produce a code is refused now rather than at login
+		if _, err := auth.TOTP(secret, time.Now()); err != nil {
+			return fail("%v", err)
+		}
+		if err := vault.Set(vault.TOTPSecret, secret); err != nil {
+			return fail("%v", err)
+		}
+		fmt.Println("TOTP secret saved, two-step verification codes will be entered automatically")
+		return ExitOK
+	case *del != "":
+		if err := vault.Delete(*del); err != nil {
+			return fail("%v", err)
+		}
+		fmt.Printf("Deleted %s\n", *del)
+		return ExitOK
+	}
+
+	names, err := vault.Names()
+	if err != nil {
+		return fail("%v", err)
+	}
+	if len(names) == 0 {
+		fmt.Println("Vault is empty")
+		return ExitOK
+	}
+	for _, name := range names {
+		fmt.Println(name)
+	}
+	return ExitOK
+}
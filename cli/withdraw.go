@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"os"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/withdraw"
+)
+
+func withdrawCmd(args []string, log *logger.Logger) int {
+	fs := newFlagSet("withdraw")
+	olderThan := fs.Int("older-than", 30, "withdraw invitations pending for more than this many days")
+	campaignID := fs.String("campaign", "", "only invitations sent for this campaign")
+	limit := fs.Int("limit", withdraw.DefaultLimit, "max invitations to withdraw")
+	dailyCap := fs.Int("daily-cap", withdraw.DefaultDailyCap, "max invitations withdrawn per day")
+	headless := fs.Bool("headless", false, "run browser headless")
+	dryRun := fs.Bool("dry-run", false, "list stale invitations without withdrawing them")
+	if code, ok := parse(fs, args); !ok {
+		return code
+	}
+
+	rec, err := withdraw.Run(withdraw.Options{
+		CampaignID: *campaignID,
+		OlderThan:  time.Duration(*olderThan) * 24 * time.Hour,
+		Limit:      *limit,
+		DailyCap:   *dailyCap,
+		DryRun:     *dryRun,
+		Headless:   *headless,
+	}, log)
+	if rec != nil {
+		printRun(os.Stdout, rec)
+	}
+	if err != nil {
+		return fail("%v", err)
+	}
+	if rec.Failed > 0 {
+		return ExitPartial
+	}
+	return ExitOK
+}
@@ -1,16 +1,17 @@
-package main
-
-import (
-	"github.com/joho/godotenv"
-	"github.com/meetm/linkedin-automation-go/api"
-	"github.com/meetm/linkedin-automation-go/pkg/logger"
-)
-
-func main() {
-	godotenv.Load()
-
-	log := logger.New()
-
-	server := api.NewServer(log)
-	server.Start()
-}
+package main
+
+import (
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/meetm/linkedin-automation-go/cli"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+)
+
+func main() {
+	godotenv.Load()
+
+	log := logger.New()
+
+	os.Exit(cli.Run(os.Args[1:], log))
+}
@@ -0,0 +1,272 @@
+package campaign
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/meetm/linkedin-automation-go/actions"
+	"github.com/meetm/linkedin-automation-go/pkg/scheduler"
+	"github.com/meetm/linkedin-automation-go/pkg/storage"
+	"github.com/meetm/linkedin-automation-go/pkg/workflow"
+	"github.com/meetm/linkedin-automation-go/search"
+)
+
+var (
+	ErrNotFound  = errors.New("campaign not found")
+	ErrExists    = errors.New("campaign already exists")
+	ErrInvalidID = errors.New("invalid campaign id")
+)
+
+type Limits struct {
+	PerRun   int `json:"perRun"`
+	Daily    int `json:"daily,omitempty"`
+	Messages int `json:"messages,omitempty"` // follow-up messages per day
+	Follows  int `json:"follows,omitempty"`  // follows of follow-only profiles per day
+}
+
+// FollowUp is one message of the sequence sent after an invitation is accepted
+type FollowUp struct {
+	AfterDays int    `json:"afterDays"` // days after acceptance
+	Template  string `json:"template"`
+}
+
+// Campaign groups the search criteria, note and limits of a recurring outreach effort
+type Campaign struct {
+	ID               string          `json:"id"`
+	Name             string          `json:"name"`
+	Search           search.Criteria `json:"search"`
+	NoteTemplate     string          `json:"noteTemplate,omitempty"`
+	Limits           Limits          `json:"limits"`
+	Headless         bool            `json:"headless,omitempty"`
+	ConnectFromCards bool            `json:"connectFromCards,omitempty"`
+	FollowOnly       string          `json:"followOnly,omitempty"` // skip, follow or review
+	NotePolicy       string          `json:"notePolicy,omitempty"` // fallback, require or skip
+	FollowUps        []FollowUp      `json:"followUps,omitempty"`
+	AutoSend         bool            `json:"autoSend,omitempty"` // send follow-ups without queuing them for approval
+	Schedule         *scheduler.Spec `json:"schedule,omitempty"`
+	CreatedAt        time.Time       `json:"createdAt"`
+	UpdatedAt        time.Time       `json:"updatedAt"`
+}
+
+var (
+	mu       sync.Mutex
+	slugChar = regexp.MustCompile(`[^a-z0-9]+`)
+
+	// the IDs Create derives from names; anything else could name a file
+	// outside the campaigns directory
+	validID = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+func (c *Campaign) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return errors.New("name is required")
+	}
+	if err := c.Search.Validate(); err != nil {
+		return err
+	}
+	if c.Limits.PerRun <= 0 {
+		return errors.New("limits.perRun must be positive")
+	}
+	if c.Limits.Daily < 0 {
+		return errors.New("limits.daily must not be negative")
+	}
+	if utf8.RuneCountInString(c.NoteTemplate) > 300 {
+		return errors.New("noteTemplate must be at most 300 characters")
+	}
+	if c.Limits.Messages < 0 {
+		return errors.New("limits.messages must not be negative")
+	}
+	if c.Limits.Follows < 0 {
+		return errors.New("limits.follows must not be negative")
+	}
+	switch actions.NotePolicy(c.NotePolicy) {
+	case "", actions.NoteFallback, actions.NoteRequire, actions.NoteSkip:
+	default:
+		return fmt.Errorf("notePolicy must be fallback, require or skip, not %q", c.NotePolicy)
+	}
+	switch c.FollowOnly {
+	case "", workflow.FollowOnlySkip, workflow.FollowOnlyFollow, workflow.FollowOnlyReview:
+	default:
+		return fmt.Errorf("followOnly must be skip, follow or review, not %q", c.FollowOnly)
+	}
+	for i, f := range c.FollowUps {
+		if strings.TrimSpace(f.Template) == "" {
+			return fmt.Errorf("followUps[%d]: template is required", i)
+		}
+		if f.AfterDays < 0 || i > 0 && f.AfterDays <= c.FollowUps[i-1].AfterDays {
+			return fmt.Errorf("followUps[%d]: afterDays must be increasing and not negative", i)
+		}
+	}
+	if c.Schedule != nil {
+		if err := c.Schedule.Validate(); err != nil {
+			return fmt.Errorf("schedule: %w", err)
+		}
+	}
+	return nil
+}
+
+// Config builds the workflow configuration for a run of this campaign
+func (c *Campaign) Config() workflow.Config {
+	return workflow.Config{
+		CampaignID:       c.ID,
+		Search:           c.Search,
+		Limit:            c.Limits.PerRun,
+		ConnectMessage:   c.NoteTemplate,
+		Headless:         c.Headless,
+		ConnectFromCards: c.ConnectFromCards,
+		FollowOnly:       c.FollowOnly,
+		FollowCap:        c.Limits.Follows,
+		NotePolicy:       actions.NotePolicy(c.NotePolicy),
+		DailyCap:         c.Limits.Daily,
+	}
+}
+
+func path(id string) (string, error) {
+	if !validID.MatchString(id) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidID, id)
+	}
+	return storage.Path("campaigns", id+".json"), nil
+}
+
+// Create validates c, assigns an ID derived from its name and persists it
+func Create(c *Campaign) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	c.ID = strings.Trim(slugChar.ReplaceAllString(strings.ToLower(c.Name), "-"), "-")
+	if c.ID == "" {
+		return errors.New("name must contain letters or digits")
+	}
+	p, err := path(c.ID)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(p); err == nil {
+		return fmt.Errorf("%w: %s", ErrExists, c.ID)
+	}
+
+	c.CreatedAt = time.Now()
+	c.UpdatedAt = c.CreatedAt
+	return storage.WriteJSON(p, c)
+}
+
+// Update replaces an existing campaign, keeping its ID and creation time
+func Update(c *Campaign) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	p, err := path(c.ID)
+	if err != nil {
+		return err
+	}
+	var old Campaign
+	if err := storage.ReadJSON(p, &old); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	c.CreatedAt = old.CreatedAt
+	c.UpdatedAt = time.Now()
+	return storage.WriteJSON(p, c)
+}
+
+func Get(id string) (*Campaign, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	p, err := path(id)
+	if err != nil {
+		return nil, err
+	}
+	var c Campaign
+	if err := storage.ReadJSON(p, &c); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func Delete(id string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	p, err := path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// List returns all campaigns ordered by name
+func List() ([]Campaign, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	files, err := os.ReadDir(storage.Path("campaigns", ""))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var list []Campaign
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		var c Campaign
+		if err := storage.ReadJSON(storage.Path("campaigns", f.Name()), &c); err != nil {
+			continue
+		}
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+// ScheduleEntries adapts scheduled campaigns for the scheduler
+func ScheduleEntries() ([]scheduler.Entry, error) {
+	list, err := List()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []scheduler.Entry
+	for _, c := range list {
+		if c.Schedule == nil {
+			continue
+		}
+		entries = append(entries, scheduler.Entry{
+			Name:   c.ID,
+			Spec:   *c.Schedule,
+			Config: c.Config(),
+		})
+	}
+	return entries, nil
+}
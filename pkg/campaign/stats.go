@@ -0,0 +1,97 @@
+package campaign
+
+import (
+	"sort"
+
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/ledger"
+)
+
+// Stats rolls up run history and the contact ledger for one campaign
+type Stats struct {
+	Runs           int                   `json:"runs"`
+	ProfilesFound  int                   `json:"profilesFound"`
+	Sent           int                   `json:"sent"`
+	Skipped        int                   `json:"skipped"`
+	Failed         int                   `json:"failed"`
+	Contacts       map[ledger.Status]int `json:"contacts"`
+	AcceptanceRate float64               `json:"acceptanceRate"`
+	Templates      []TemplateStats       `json:"templates,omitempty"`
+}
+
+// TemplateStats is the acceptance of invitations sent with one note template
+type TemplateStats struct {
+	Template       string  `json:"template"` // empty for invitations without a note
+	Sent           int     `json:"sent"`
+	Accepted       int     `json:"accepted"`
+	AcceptanceRate float64 `json:"acceptanceRate"`
+}
+
+func StatsFor(id string) (Stats, error) {
+	stats := Stats{Contacts: make(map[ledger.Status]int)}
+
+	runs, err := history.List()
+	if err != nil {
+		return stats, err
+	}
+	for _, r := range runs {
+		if r.CampaignID != id || r.Kind != "" {
+			continue
+		}
+		stats.Runs++
+		stats.ProfilesFound += r.ProfilesFound
+		stats.Sent += r.Sent
+		stats.Skipped += r.Skipped
+		stats.Failed += r.Failed
+	}
+
+	contacts, err := ledger.List(id)
+	if err != nil {
+		return stats, err
+	}
+	for _, c := range contacts {
+		stats.Contacts[c.Status]++
+	}
+	if invited := len(contacts) - stats.Contacts[ledger.StatusFollowed]; invited > 0 {
+		stats.AcceptanceRate = float64(stats.Contacts[ledger.StatusAccepted]) / float64(invited)
+	}
+	stats.Templates = byTemplate(contacts)
+	return stats, nil
+}
+
+// TemplateStatsFor returns acceptance per note template, for one campaign or
+// all of them when id is empty, most used template first
+func TemplateStatsFor(id string) ([]TemplateStats, error) {
+	contacts, err := ledger.List(id)
+	if err != nil {
+		return nil, err
+	}
+	return byTemplate(contacts), nil
+}
+
+func byTemplate(contacts []ledger.Entry) []TemplateStats {
+	index := map[string]int{}
+	var list []TemplateStats
+	for _, c := range contacts {
+		if c.Status == ledger.StatusFollowed {
+			continue
+		}
+		i, ok := index[c.Template]
+		if !ok {
+			i = len(list)
+			index[c.Template] = i
+			list = append(list, TemplateStats{Template: c.Template})
+		}
+		list[i].Sent++
+		if c.Status == ledger.StatusAccepted {
+			list[i].Accepted++
+		}
+	}
+	for i := range list {
+		list[i].AcceptanceRate = float64(list[i].Accepted) / float64(list[i].Sent)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Sent > list[j].Sent
+	})
+	return list
+}
@@ -0,0 +1,226 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/ledger"
+)
+
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+	FormatXLSX  Format = "xlsx"
+)
+
+// what can be exported
+const (
+	KindResults  = "results"
+	KindContacts = "contacts"
+	KindRuns     = "runs"
+)
+
+// Filter narrows an export; zero values match everything
+type Filter struct {
+	From       time.Time
+	To         time.Time
+	Outcome    string
+	CampaignID string
+}
+
+// Table is a rectangular export, written out by Write in any format
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatCSV, FormatJSONL, FormatXLSX:
+		return f, nil
+	case "":
+		return FormatCSV, nil
+	}
+	return "", fmt.Errorf("unknown export format %q (csv, jsonl or xlsx)", s)
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatJSONL:
+		return "application/x-ndjson"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv"
+}
+
+// ParseDate accepts 2006-01-02 or RFC 3339. A bare date used as the end of a
+// range covers that whole day.
+func ParseDate(s string, endOfDay bool) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", s)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return t, nil
+}
+
+func (f Filter) matchTime(t time.Time) bool {
+	if !f.From.IsZero() && t.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && t.After(f.To) {
+		return false
+	}
+	return true
+}
+
+// Build returns the table for kind
+func Build(kind string, f Filter) (Table, error) {
+	switch kind {
+	case KindResults:
+		return Results(f)
+	case KindContacts:
+		return Contacts(f)
+	case KindRuns:
+		return Runs(f)
+	}
+	return Table{}, fmt.Errorf("unknown export %q (results, contacts or runs)", kind)
+}
+
+// Results exports one row per processed profile across all runs
+func Results(f Filter) (Table, error) {
+	t := Table{Headers: []string{"run_id", "campaign_id", "profile_url", "name", "headline", "location", "outcome", "reason", "at"}}
+
+	runs, err := history.List()
+	if err != nil {
+		return t, err
+	}
+	for _, run := range runs {
+		if f.CampaignID != "" && run.CampaignID != f.CampaignID {
+			continue
+		}
+		for _, res := range run.Results {
+			if f.Outcome != "" && string(res.Outcome) != f.Outcome {
+				continue
+			}
+			if !f.matchTime(res.At) {
+				continue
+			}
+			t.Rows = append(t.Rows, []string{
+				run.ID, run.CampaignID, res.ProfileURL, res.Name, res.Headline, res.Location,
+				string(res.Outcome), res.Reason, formatTime(res.At),
+			})
+		}
+	}
+	return t, nil
+}
+
+// Contacts exports the contact ledger; Outcome filters on ledger status
+func Contacts(f Filter) (Table, error) {
+	t := Table{Headers: []string{"profile_url", "name", "headline", "campaign_id", "run_id", "status", "with_note", "template",
+		"sent_at", "updated_at", "checked_at", "accepted_at", "withdrawn_at", "followed_at",
+		"follow_ups", "last_message_at", "replied_at"}}
+
+	entries, err := ledger.List(f.CampaignID)
+	if err != nil {
+		return t, err
+	}
+	for _, e := range entries {
+		if f.Outcome != "" && string(e.Status) != f.Outcome {
+			continue
+		}
+		if !f.matchTime(e.SentAt) {
+			continue
+		}
+		t.Rows = append(t.Rows, []string{
+			e.ProfileURL, e.Name, e.Headline, e.CampaignID, e.RunID, string(e.Status), strconv.FormatBool(e.WithNote), e.Template,
+			formatTime(e.SentAt), formatTime(e.UpdatedAt), formatTime(e.CheckedAt), formatTime(e.AcceptedAt), formatTime(e.WithdrawnAt), formatTime(e.FollowedAt),
+			strconv.Itoa(e.FollowUps), formatTime(e.LastMessageAt), formatTime(e.RepliedAt),
+		})
+	}
+	return t, nil
+}
+
+// Runs exports one summary row per run; Outcome filters on run status
+func Runs(f Filter) (Table, error) {
+	t := Table{Headers: []string{"run_id", "kind", "campaign_id", "source", "search", "status", "error",
+		"started_at", "finished_at", "profiles_found", "sent", "skipped", "failed", "withdrawn", "followed", "unconfirmed"}}
+
+	runs, err := history.List()
+	if err != nil {
+		return t, err
+	}
+	for _, run := range runs {
+		if f.CampaignID != "" && run.CampaignID != f.CampaignID {
+			continue
+		}
+		if f.Outcome != "" && string(run.Status) != f.Outcome {
+			continue
+		}
+		if !f.matchTime(run.StartedAt) {
+			continue
+		}
+		t.Rows = append(t.Rows, []string{
+			run.ID, run.Kind, run.CampaignID, run.Source, run.Criteria.String(), string(run.Status), run.Error,
+			formatTime(run.StartedAt), formatTime(run.FinishedAt),
+			strconv.Itoa(run.ProfilesFound), strconv.Itoa(run.Sent), strconv.Itoa(run.Skipped), strconv.Itoa(run.Failed),
+			strconv.Itoa(run.Withdrawn), strconv.Itoa(run.Followed), strconv.Itoa(run.Unconfirmed),
+		})
+	}
+	return t, nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// Write encodes t to w in the given format
+func Write(w io.Writer, format Format, t Table) error {
+	switch format {
+	case FormatJSONL:
+		enc := json.NewEncoder(w)
+		for _, row := range t.Rows {
+			obj := make(map[string]string, len(t.Headers))
+			for i, h := range t.Headers {
+				obj[h] = row[i]
+			}
+			if err := enc.Encode(obj); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case FormatXLSX:
+		return writeXLSX(w, t)
+
+	default:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(t.Headers); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(t.Rows); err != nil {
+			return err
+		}
+		return cw.Error()
+	}
+}
@@ -0,0 +1,299 @@
+package followup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/actions"
+	"github.com/meetm/linkedin-automation-go/pkg/campaign"
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/ledger"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/note"
+	"github.com/meetm/linkedin-automation-go/pkg/outcome"
+	"github.com/meetm/linkedin-automation-go/pkg/review"
+	"github.com/meetm/linkedin-automation-go/pkg/workflow"
+	"github.com/meetm/linkedin-automation-go/utils"
+)
+
+// DefaultDailyCap applies to campaigns without limits.messages
+const DefaultDailyCap = 20
+
+type Options struct {
+	RunID      string
+	CampaignID string // empty runs the sequences of every campaign
+	DryRun     bool
+	Headless   bool
+}
+
+// task is a follow-up message ready to send
+type task struct {
+	campaign *campaign.Campaign
+	entry    ledger.Entry
+	step     int
+	text     string
+	reviewID string
+}
+
+// Run sends the follow-up messages that are due. A message is due once its
+// step's afterDays have passed since the invitation was accepted; sequences
+// stop for contacts who replied. Each message is queued for approval and sent
+// on a later run once approved, unless the campaign has autoSend.
+func Run(opts Options, log *logger.Logger) (*history.Record, error) {
+	if opts.RunID == "" {
+		opts.RunID = history.NewID()
+	}
+
+	rec := &history.Record{
+		ID:         opts.RunID,
+		Kind:       history.KindMessage,
+		CampaignID: opts.CampaignID,
+		DryRun:     opts.DryRun,
+		Status:     history.StatusRunning,
+		StartedAt:  time.Now(),
+	}
+	save(rec, log)
+
+	err := run(opts, rec, log)
+
+	rec.FinishedAt = time.Now()
+	if err != nil {
+		rec.Fail(err)
+	} else {
+		rec.Status = history.StatusCompleted
+	}
+	save(rec, log)
+	return rec, err
+}
+
+func run(opts Options, rec *history.Record, log *logger.Logger) (err error) {
+	defer outcome.Recover(&err, log)
+	campaigns, err := campaignsFor(opts.CampaignID)
+	if err != nil {
+		return err
+	}
+
+	var tasks []task
+	for _, c := range campaigns {
+		due, err := dueTasks(c, opts.DryRun, rec, log)
+		if err != nil {
+			return err
+		}
+		tasks = append(tasks, due...)
+	}
+	rec.ProfilesFound = len(tasks)
+	if len(tasks) == 0 {
+		log.Printf("No follow-up messages due")
+		return nil
+	}
+
+	if opts.DryRun {
+		for _, t := range tasks {
+			log.Printf("Dry run: would message %s: %q", t.entry.ProfileURL, t.text)
+			add(rec, t.entry, outcome.DryRun, fmt.Sprintf("step %d", t.step+1))
+		}
+		return nil
+	}
+
+	browser, page, err := workflow.Session(workflow.Config{Headless: opts.Headless}, log)
+	if err != nil {
+		return err
+	}
+	defer workflow.CloseBrowser(browser, log)
+
+	for i, t := range tasks {
+		if capReached(t.campaign, log) {
+			continue
+		}
+
+		replied, err := actions.HasReplied(page, t.entry.ProfileURL, t.entry.Name, log)
+		if err != nil {
+			log.Printf("Could not open conversation with %s: %v", t.entry.ProfileURL, err)
+			add(rec, t.entry, outcome.Failed, err.Error())
+			continue
+		}
+		if replied {
+			log.Printf("%s replied, stopping their follow-ups", displayName(t.entry))
+			update(t.entry, log, func(e *ledger.Entry) { e.RepliedAt = time.Now() })
+			add(rec, t.entry, outcome.Skipped, "replied")
+			continue
+		}
+
+		utils.RandomSleep(1000, 2000)
+		if err := actions.SendMessage(page, t.entry.ProfileURL, t.text, log); err != nil {
+			log.Printf("Failed to message %s: %v", t.entry.ProfileURL, err)
+			add(rec, t.entry, outcome.Failed, err.Error())
+			continue
+		}
+
+		log.Printf("Follow-up %d sent to %s", t.step+1, displayName(t.entry))
+		add(rec, t.entry, outcome.Sent, fmt.Sprintf("step %d", t.step+1))
+		update(t.entry, log, func(e *ledger.Entry) {
+			e.FollowUps = t.step + 1
+			e.LastMessageAt = time.Now()
+		})
+		if t.reviewID != "" {
+			if err := review.MarkDone(t.reviewID); err != nil {
+				log.Printf("Failed to update review queue: %v", err)
+			}
+		}
+		save(rec, log)
+
+		if i < len(tasks)-1 {
+			utils.LongRandomSleep(8, 15)
+		}
+	}
+
+	log.Printf("Follow-ups complete! Sent: %d, Skipped: %d, Failed: %d", rec.Sent, rec.Skipped, rec.Failed)
+	return nil
+}
+
+func campaignsFor(id string) ([]*campaign.Campaign, error) {
+	if id != "" {
+		c, err := campaign.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		return []*campaign.Campaign{c}, nil
+	}
+
+	list, err := campaign.List()
+	if err != nil {
+		return nil, err
+	}
+	var campaigns []*campaign.Campaign
+	for i := range list {
+		if len(list[i].FollowUps) > 0 {
+			campaigns = append(campaigns, &list[i])
+		}
+	}
+	return campaigns, nil
+}
+
+// dueTasks returns the messages of c that can be sent now, queueing them
+// for review first unless the campaign has autoSend
+func dueTasks(c *campaign.Campaign, dryRun bool, rec *history.Record, log *logger.Logger) ([]task, error) {
+	entries, err := ledger.List(c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var tasks []task
+	for _, e := range entries {
+		if e.Status != ledger.StatusAccepted || e.AcceptedAt.IsZero() || !e.RepliedAt.IsZero() {
+			continue
+		}
+		step := e.FollowUps
+		if step >= len(c.FollowUps) {
+			continue
+		}
+		if now.Before(e.AcceptedAt.AddDate(0, 0, c.FollowUps[step].AfterDays)) {
+			continue
+		}
+
+		text, err := note.RenderMessage(c.FollowUps[step].Template, vars(e))
+		if err != nil {
+			log.Printf("Skipping %s: %v", e.ProfileURL, err)
+			add(rec, e, outcome.Skipped, err.Error())
+			continue
+		}
+
+		t := task{campaign: c, entry: e, step: step, text: text}
+		if c.AutoSend || dryRun {
+			tasks = append(tasks, t)
+			continue
+		}
+
+		item, queued, err := review.Find(review.KindMessage, e.ProfileURL, step)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case !queued:
+			if _, err := review.Add(review.Item{
+				Kind:       review.KindMessage,
+				CampaignID: c.ID,
+				ProfileURL: e.ProfileURL,
+				Name:       e.Name,
+				Step:       step,
+				Text:       text,
+			}); err != nil {
+				return nil, err
+			}
+			log.Printf("Queued follow-up %d to %s for review", step+1, displayName(e))
+			add(rec, e, outcome.Queued, "awaiting approval")
+		case item.Status == review.StatusApproved:
+			t.text, t.reviewID = item.Text, item.ID
+			tasks = append(tasks, t)
+		case item.Status == review.StatusRejected:
+			// a rejected message is skipped and the sequence moves on
+			update(e, log, func(le *ledger.Entry) { le.FollowUps = step + 1 })
+		}
+	}
+	return tasks, nil
+}
+
+// capReached reports whether the campaign's daily message cap has been used up
+func capReached(c *campaign.Campaign, log *logger.Logger) bool {
+	limit := c.Limits.Messages
+	if limit <= 0 {
+		limit = DefaultDailyCap
+	}
+
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	sent, err := ledger.CountMessagedSince(c.ID, midnight)
+	if err != nil {
+		log.Printf("Could not read contact ledger: %v", err)
+		return false
+	}
+	if sent >= limit {
+		log.Printf("Daily message cap of %d reached for campaign %s", limit, c.ID)
+		return true
+	}
+	return false
+}
+
+func vars(e ledger.Entry) map[string]string {
+	v := map[string]string{}
+	if e.Name != "" {
+		v["name"] = e.Name
+		v["firstName"], _, _ = strings.Cut(e.Name, " ")
+	}
+	if e.Headline != "" {
+		v["headline"] = e.Headline
+	}
+	return v
+}
+
+func add(rec *history.Record, e ledger.Entry, o outcome.Outcome, reason string) {
+	rec.Count(o)
+	rec.Add(history.Result{
+		ProfileURL: e.ProfileURL,
+		Name:       e.Name,
+		Headline:   e.Headline,
+		Outcome:    o,
+		Reason:     reason,
+	})
+}
+
+func update(e ledger.Entry, log *logger.Logger, fn func(*ledger.Entry)) {
+	if err := ledger.Update(e.Identity(), fn); err != nil {
+		log.Printf("Failed to update contact ledger: %v", err)
+	}
+}
+
+func displayName(e ledger.Entry) string {
+	if e.Name != "" {
+		return e.Name
+	}
+	return e.ProfileURL
+}
+
+func save(rec *history.Record, log *logger.Logger) {
+	if err := history.Save(rec); err != nil {
+		log.Printf("Failed to save run history: %v", err)
+	}
+}
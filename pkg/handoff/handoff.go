@@ -0,0 +1,174 @@
+package handoff
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+)
+
+// Decision is a person's answer to a handoff
+type Decision string
+
+const (
+	Continue Decision = "continue" // solved in the browser, carry on
+	Abort    Decision = "abort"
+)
+
+// what a request asks a person to do
+const (
+	KindCheckpoint = "checkpoint" // solve a security checkpoint in the browser
+	KindTwoFactor  = "two-factor" // enter a two-step verification code
+)
+
+// Answer is a person's reply to a request; Code is the verification code
+// for KindTwoFactor
+type Answer struct {
+	Decision Decision `json:"decision"`
+	Code     string   `json:"code,omitempty"`
+}
+
+var (
+	ErrNotWaiting = errors.New("run is not waiting for a human")
+	ErrTimeout    = errors.New("nobody answered the handoff in time")
+	ErrNoCode     = errors.New("a verification code is required to continue")
+)
+
+// Request is a run paused until a person acts in its browser
+type Request struct {
+	RunID  string    `json:"runId"`
+	Kind   string    `json:"kind"`
+	Reason string    `json:"reason"`
+	URL    string    `json:"url,omitempty"`
+	Headed bool      `json:"headed"` // the browser window can be used directly
+	Since  time.Time `json:"since"`
+}
+
+type waiting struct {
+	req    Request
+	answer chan Answer
+	done   chan struct{} // closed when Wait returns
+}
+
+var (
+	mu      sync.Mutex
+	pending = map[string]*waiting{}
+)
+
+// OnRequest, when set, is called with every new request, e.g. to ask on
+// the terminal. It must not block.
+var OnRequest func(Request)
+
+// Wait announces req as a "needs_human" event and to the webhook in
+// LINKEDIN_WEBHOOK_URL, then blocks until Resolve is called for the run or
+// timeout passes. A zero timeout waits indefinitely.
+func Wait(req Request, timeout time.Duration, log *logger.Logger) (Answer, error) {
+	req.Since = time.Now()
+	w := &waiting{req: req, answer: make(chan Answer, 1), done: make(chan struct{})}
+
+	mu.Lock()
+	pending[req.RunID] = w
+	mu.Unlock()
+	defer func() {
+		mu.Lock()
+		delete(pending, req.RunID)
+		mu.Unlock()
+		close(w.done)
+	}()
+
+	if req.Kind == KindTwoFactor {
+		log.Printf("Needs a human: %s. Send it with POST /api/runs/%s/continue {\"code\": \"...\"}, or /abort", req.Reason, req.RunID)
+	} else {
+		log.Printf("Needs a human: %s. Continue or abort with POST /api/runs/%s/continue or /abort", req.Reason, req.RunID)
+	}
+	log.Event("needs_human", req)
+	notify(req, log)
+	if OnRequest != nil {
+		OnRequest(req)
+	}
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		expired = time.After(timeout)
+	}
+	select {
+	case a := <-w.answer:
+		log.Event("handoff_resolved", map[string]string{"runId": req.RunID, "decision": string(a.Decision)})
+		return a, nil
+	case <-expired:
+		return Answer{Decision: Abort}, ErrTimeout
+	}
+}
+
+// Resolve answers the request a run is waiting on
+func Resolve(runID string, a Answer) error {
+	mu.Lock()
+	defer mu.Unlock()
+	w, ok := pending[runID]
+	if !ok {
+		return ErrNotWaiting
+	}
+	if w.req.Kind == KindTwoFactor && a.Decision == Continue && a.Code == "" {
+		return ErrNoCode
+	}
+	select {
+	case w.answer <- a:
+	default:
+		// already answered, the first decision stands
+	}
+	return nil
+}
+
+// Done returns a channel closed once the run's request has been answered or
+// timed out, and one already closed if the run is not waiting
+func Done(runID string) <-chan struct{} {
+	mu.Lock()
+	defer mu.Unlock()
+	if w, ok := pending[runID]; ok {
+		return w.done
+	}
+	done := make(chan struct{})
+	close(done)
+	return done
+}
+
+// Pending lists the runs waiting for a human, oldest first
+func Pending() []Request {
+	mu.Lock()
+	defer mu.Unlock()
+	reqs := make([]Request, 0, len(pending))
+	for _, w := range pending {
+		reqs = append(reqs, w.req)
+	}
+	sort.Slice(reqs, func(i, j int) bool { return reqs[i].Since.Before(reqs[j].Since) })
+	return reqs
+}
+
+// notify posts req to LINKEDIN_WEBHOOK_URL, if set
+func notify(req Request, log *logger.Logger) {
+	url := os.Getenv("LINKEDIN_WEBHOOK_URL")
+	if url == "" {
+		return
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"event": "needs_human", "request": req})
+	if err != nil {
+		return
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		log.Printf("Webhook failed: %v", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		log.Printf("Webhook returned %s", resp.Status)
+	}
+}
@@ -0,0 +1,239 @@
+package history
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/outcome"
+	"github.com/meetm/linkedin-automation-go/pkg/storage"
+	"github.com/meetm/linkedin-automation-go/search"
+)
+
+type Status string
+
+const (
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+
+	// StatusNeedsHuman is a run paused at a security checkpoint until
+	// someone solves it and continues or aborts it
+	StatusNeedsHuman Status = "needs-human"
+
+	// StatusStopped is a run that ended early with profiles left, e.g. at
+	// the daily cap or a rate limit, and can be resumed later
+	StatusStopped Status = "stopped"
+)
+
+// maintenance jobs recorded alongside connect runs, which leave Kind empty
+const (
+	KindWithdraw = "withdraw"
+	KindMessage  = "message"
+)
+
+// where a run's profiles came from
+const (
+	SourceSearch = "search"
+	SourceImport = "import"
+)
+
+var ErrRunNotFound = errors.New("run not found")
+
+type Result struct {
+	ProfileURL string          `json:"profileUrl"`
+	Name       string          `json:"name,omitempty"`
+	Headline   string          `json:"headline,omitempty"`
+	Location   string          `json:"location,omitempty"`
+	Outcome    outcome.Outcome `json:"outcome"`
+	Reason     string          `json:"reason,omitempty"`
+	At         time.Time       `json:"at"`
+}
+
+// Record is the persisted summary of a single workflow run
+type Record struct {
+	ID         string          `json:"id"`
+	Kind       string          `json:"kind,omitempty"`
+	CampaignID string          `json:"campaignId,omitempty"`
+	Keyword    string          `json:"keyword"`
+	Criteria   search.Criteria `json:"criteria"`
+	Source     string          `json:"source,omitempty"`
+	Limit      int             `json:"limit"`
+	DryRun     bool            `json:"dryRun,omitempty"`
+	Status     Status          `json:"status"`
+	Error      string          `json:"error,omitempty"`
+	Stack      string          `json:"stack,omitempty"`      // of a run that panicked
+	StopReason string          `json:"stopReason,omitempty"` // why a stopped run ended early
+	StartedAt  time.Time       `json:"startedAt"`
+	FinishedAt time.Time       `json:"finishedAt,omitzero"`
+
+	// totals derived from Outcomes by Count and SetOutcomes, kept for
+	// readers of older records
+	ProfilesFound int `json:"profilesFound"`
+	Sent          int `json:"sent"`
+	Skipped       int `json:"skipped"`
+	Failed        int `json:"failed"`
+	Withdrawn     int `json:"withdrawn,omitempty"`
+	Followed      int `json:"followed,omitempty"`
+	Unconfirmed   int `json:"unconfirmed,omitempty"`
+
+	Outcomes outcome.Counts `json:"outcomes,omitempty"`
+
+	Results []Result `json:"results,omitempty"`
+}
+
+var mu sync.Mutex
+
+// NewID returns a sortable, unique run identifier
+func NewID() string {
+	b := make([]byte, 3)
+	rand.Read(b)
+	return time.Now().UTC().Format("20060102-150405") + "-" + hex.EncodeToString(b)
+}
+
+// Save writes the record to disk, replacing any previous version
+func Save(rec *Record) error {
+	if err := storage.CheckName(rec.ID); err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	return storage.WriteJSON(storage.Path("runs", rec.ID+".json"), rec)
+}
+
+// SetStatus updates the status of a saved run
+func SetStatus(id string, status Status) error {
+	rec, err := Load(id)
+	if err != nil {
+		return err
+	}
+	rec.Status = status
+	return Save(rec)
+}
+
+// Load reads a single run by ID
+func Load(id string) (*Record, error) {
+	if storage.CheckName(id) != nil {
+		// no run can have it
+		return nil, ErrRunNotFound
+	}
+	mu.Lock()
+	defer mu.Unlock()
+
+	var rec Record
+	if err := storage.ReadJSON(storage.Path("runs", id+".json"), &rec); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrRunNotFound
+		}
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// List returns all recorded runs, newest first
+func List() ([]Record, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	entries, err := os.ReadDir(storage.Path("runs", ""))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var records []Record
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		var rec Record
+		if err := storage.ReadJSON(storage.Path("runs", e.Name()), &rec); err != nil {
+			continue
+		}
+		records = append(records, rec)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].StartedAt.After(records[j].StartedAt)
+	})
+	return records, nil
+}
+
+// CountSince counts results with outcome o at or after since, across runs of kind
+func CountSince(kind string, o outcome.Outcome, since time.Time) (int, error) {
+	records, err := List()
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, r := range records {
+		if r.Kind != kind {
+			continue
+		}
+		for _, res := range r.Results {
+			if res.Outcome == o && !res.At.Before(since) {
+				n++
+			}
+		}
+	}
+	return n, nil
+}
+
+// Add appends a per-profile result to the record, stamping it with the current time
+func (r *Record) Add(res Result) {
+	if res.At.IsZero() {
+		res.At = time.Now()
+	}
+	r.Results = append(r.Results, res)
+}
+
+// Count adds one profile with outcome o to the record's counts
+func (r *Record) Count(o outcome.Outcome) {
+	if r.Outcomes == nil {
+		r.Outcomes = outcome.Counts{}
+	}
+	r.Outcomes[o]++
+	r.tally()
+}
+
+// SetOutcomes replaces the record's counts
+func (r *Record) SetOutcomes(c outcome.Counts) {
+	r.Outcomes = c
+	r.tally()
+}
+
+func (r *Record) tally() {
+	r.Sent = r.Outcomes.Sent()
+	r.Skipped = r.Outcomes.Skipped()
+	r.Failed = r.Outcomes.Failed()
+	r.Withdrawn = r.Outcomes[outcome.Withdrawn]
+	r.Followed = r.Outcomes[outcome.Followed]
+	r.Unconfirmed = r.Outcomes[outcome.Unconfirmed]
+}
+
+// Fail marks the record failed with err, keeping the stack of a panic
+func (r *Record) Fail(err error) {
+	r.Status = StatusFailed
+	r.Error = err.Error()
+	var p *outcome.PanicError
+	if errors.As(err, &p) {
+		r.Stack = p.Stack
+	}
+}
+
+// Has reports whether a result was already recorded for profileURL
+func (r *Record) Has(profileURL string) bool {
+	for _, res := range r.Results {
+		if res.ProfileURL == profileURL {
+			return true
+		}
+	}
+	return false
+}
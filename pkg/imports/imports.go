@@ -0,0 +1,210 @@
+package imports
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/meetm/linkedin-automation-go/pkg/ledger"
+	"github.com/meetm/linkedin-automation-go/pkg/profileurl"
+	"github.com/meetm/linkedin-automation-go/pkg/workflow"
+)
+
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+var ErrNoURLColumn = errors.New("no profile URL column found (expected url, profileUrl, profile or linkedin)")
+
+// column / field names accepted for the profile URL, compared case-insensitively
+var urlKeys = []string{"url", "profileurl", "profile_url", "profile", "linkedin", "linkedinurl"}
+
+type Rejected struct {
+	Row    int    `json:"row"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+// Report summarises what happened to each imported row
+type Report struct {
+	Rows             int        `json:"rows"`
+	Accepted         int        `json:"accepted"`
+	Duplicates       int        `json:"duplicates"`
+	AlreadyContacted int        `json:"alreadyContacted"`
+	Invalid          []Rejected `json:"invalid,omitempty"`
+}
+
+// DetectFormat guesses the format from a file name, falling back to the content
+func DetectFormat(name string, data []byte) Format {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".jsonl", ".ndjson", ".json":
+		return FormatJSONL
+	case ".csv":
+		return FormatCSV
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return FormatJSONL
+	}
+	return FormatCSV
+}
+
+// Parse reads profile rows, canonicalizes their URLs and drops invalid rows,
+// duplicates and profiles already in the contact ledger. Every column or
+// field other than the URL becomes a note variable for that profile.
+func Parse(r io.Reader, format Format) ([]workflow.Target, Report, error) {
+	var rows []row
+	var err error
+	switch format {
+	case FormatJSONL:
+		rows, err = readJSONL(r)
+	default:
+		rows, err = readCSV(r)
+	}
+	if err != nil {
+		return nil, Report{}, err
+	}
+
+	report := Report{Rows: len(rows)}
+	var targets []workflow.Target
+
+rows:
+	for _, rw := range rows {
+		id, err := profileurl.Parse(rw.url)
+		if err != nil {
+			report.Invalid = append(report.Invalid, Rejected{Row: rw.line, Value: rw.url, Reason: err.Error()})
+			continue
+		}
+		for _, t := range targets {
+			if t.Identity.Matches(id) {
+				report.Duplicates++
+				continue rows
+			}
+		}
+
+		if _, ok, err := ledger.Find(id); err != nil {
+			return nil, report, err
+		} else if ok {
+			report.AlreadyContacted++
+			continue
+		}
+
+		targets = append(targets, workflow.Target{ProfileURL: id.URL(), Identity: id, Vars: rw.vars})
+	}
+
+	report.Accepted = len(targets)
+	return targets, report, nil
+}
+
+type row struct {
+	line int
+	url  string
+	vars map[string]string
+}
+
+func isURLKey(name string) bool {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, k := range urlKeys {
+		if name == k {
+			return true
+		}
+	}
+	return false
+}
+
+func readCSV(r io.Reader) ([]row, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("csv header: %w", err)
+	}
+
+	urlCol := -1
+	for i, name := range header {
+		if isURLKey(strings.TrimPrefix(name, "\ufeff")) {
+			urlCol = i
+			break
+		}
+	}
+	if urlCol < 0 {
+		return nil, ErrNoURLColumn
+	}
+
+	var rows []row
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("csv line %d: %w", line, err)
+		}
+		if urlCol >= len(record) {
+			rows = append(rows, row{line: line})
+			continue
+		}
+
+		rw := row{line: line, url: record[urlCol], vars: make(map[string]string)}
+		for i, value := range record {
+			if i != urlCol && i < len(header) && strings.TrimSpace(value) != "" {
+				rw.vars[strings.TrimSpace(header[i])] = strings.TrimSpace(value)
+			}
+		}
+		rows = append(rows, rw)
+	}
+	return rows, nil
+}
+
+// each line is an object with a URL field and either a "vars" object or
+// further top-level string fields
+func readJSONL(r io.Reader) ([]row, error) {
+	var rows []row
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" {
+			continue
+		}
+
+		var obj map[string]interface{}
+		if err := json.Unmarshal([]byte(text), &obj); err != nil {
+			return nil, fmt.Errorf("jsonl line %d: %w", line, err)
+		}
+
+		rw := row{line: line, vars: make(map[string]string)}
+		for k, v := range obj {
+			switch {
+			case isURLKey(k):
+				rw.url, _ = v.(string)
+			case k == "vars":
+				if m, ok := v.(map[string]interface{}); ok {
+					for vk, vv := range m {
+						rw.vars[vk] = fmt.Sprint(vv)
+					}
+				}
+			default:
+				if s, ok := v.(string); ok {
+					rw.vars[k] = s
+				}
+			}
+		}
+		rows = append(rows, rw)
+	}
+	return rows, sc.Err()
+}
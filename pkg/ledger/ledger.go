@@ -0,0 +1,264 @@
+package ledger
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/profileurl"
+	"github.com/meetm/linkedin-automation-go/pkg/storage"
+)
+
+type Status string
+
+const (
+	StatusSent      Status = "sent"
+	StatusPending   Status = "pending"
+	StatusAccepted  Status = "accepted"
+	StatusWithdrawn Status = "withdrawn"
+	StatusIgnored   Status = "ignored"  // still pending long after it was sent
+	StatusFollowed  Status = "followed" // followed instead, the profile offers no Connect
+)
+
+// Entry remembers a profile we have sent an invitation to or followed, so
+// later runs never contact the same person twice
+type Entry struct {
+	ProfileURL string    `json:"profileUrl"`
+	Vanity     string    `json:"vanity,omitempty"`
+	MemberID   string    `json:"memberId,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	Headline   string    `json:"headline,omitempty"`
+	CampaignID string    `json:"campaignId,omitempty"`
+	RunID      string    `json:"runId,omitempty"`
+	Status     Status    `json:"status"`
+	WithNote   bool      `json:"withNote,omitempty"`
+	Template   string    `json:"template,omitempty"` // note template the invitation was rendered from
+	SentAt     time.Time `json:"sentAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+
+	CheckedAt   time.Time `json:"checkedAt,omitzero"`
+	AcceptedAt  time.Time `json:"acceptedAt,omitzero"`
+	WithdrawnAt time.Time `json:"withdrawnAt,omitzero"`
+	FollowedAt  time.Time `json:"followedAt,omitzero"`
+
+	// follow-up messages after acceptance
+	FollowUps     int       `json:"followUps,omitempty"` // steps of the sequence done
+	LastMessageAt time.Time `json:"lastMessageAt,omitzero"`
+	RepliedAt     time.Time `json:"repliedAt,omitzero"`
+}
+
+const ledgerFile = "ledger.json"
+
+var ErrNotFound = errors.New("profile not in contact ledger")
+
+var (
+	mu       sync.Mutex
+	entries  map[string]*Entry
+	modified time.Time // of the file when entries were read or written
+)
+
+// load reads the ledger unless the cached copy is current. A CLI command
+// can change the file while serve or the scheduler runs, so it is read
+// again whenever its modification time changes.
+func load() error {
+	path := storage.Path(ledgerFile)
+	var mtime time.Time
+	if info, err := os.Stat(path); err == nil {
+		mtime = info.ModTime()
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if entries != nil && mtime.Equal(modified) {
+		return nil
+	}
+
+	fresh := make(map[string]*Entry)
+	if err := storage.ReadJSON(path, &fresh); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+	entries, modified = fresh, mtime
+	return nil
+}
+
+func save() error {
+	path := storage.Path(ledgerFile)
+	if err := storage.WriteJSON(path, entries); err != nil {
+		return err
+	}
+	if info, err := os.Stat(path); err == nil {
+		modified = info.ModTime()
+	}
+	return nil
+}
+
+func (e Entry) Identity() profileurl.Identity {
+	return profileurl.Identity{Vanity: e.Vanity, MemberID: e.MemberID}
+}
+
+// find returns the key of the entry for the same member as id
+func find(id profileurl.Identity) (string, bool) {
+	if _, ok := entries[id.URL()]; ok {
+		return id.URL(), true
+	}
+	for key, e := range entries {
+		if e.Identity().Matches(id) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// Find returns the ledger entry for the member identified by id, if any
+func Find(id profileurl.Identity) (Entry, bool, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := load(); err != nil {
+		return Entry{}, false, err
+	}
+	key, ok := find(id)
+	if !ok {
+		return Entry{}, false, nil
+	}
+	return *entries[key], true, nil
+}
+
+// Put inserts or replaces the entry for the same member, keyed by the
+// canonical URL of everything known about them
+func Put(e Entry) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := load(); err != nil {
+		return err
+	}
+
+	id := e.Identity()
+	if parsed, err := profileurl.Parse(e.ProfileURL); err == nil {
+		id = id.Merge(parsed)
+	}
+	if key, ok := find(id); ok {
+		id = id.Merge(entries[key].Identity())
+		delete(entries, key)
+	}
+
+	e.Vanity, e.MemberID = id.Vanity, id.MemberID
+	e.ProfileURL = id.URL()
+	e.UpdatedAt = time.Now()
+	entries[e.ProfileURL] = &e
+	return save()
+}
+
+// RecordSent adds a freshly sent invitation to the ledger
+func RecordSent(e Entry) error {
+	e.Status = StatusSent
+	e.SentAt = time.Now()
+	return Put(e)
+}
+
+// Open reports whether the invitation may still be accepted
+func (e Entry) Open() bool {
+	return e.Status == StatusSent || e.Status == StatusPending || e.Status == StatusIgnored
+}
+
+// SetStatus records the status of an invitation as observed at at, and
+// reports whether it changed
+func SetStatus(id profileurl.Identity, status Status, at time.Time) (bool, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := load(); err != nil {
+		return false, err
+	}
+	key, ok := find(id)
+	if !ok {
+		return false, ErrNotFound
+	}
+
+	e := entries[key]
+	e.CheckedAt = at
+	changed := e.Status != status
+	if changed {
+		e.Status = status
+		e.UpdatedAt = time.Now()
+		switch status {
+		case StatusAccepted:
+			e.AcceptedAt = at
+		case StatusWithdrawn:
+			e.WithdrawnAt = at
+		}
+	}
+	return changed, save()
+}
+
+// Update applies fn to the entry for the member identified by id
+func Update(id profileurl.Identity, fn func(*Entry)) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := load(); err != nil {
+		return err
+	}
+	key, ok := find(id)
+	if !ok {
+		return ErrNotFound
+	}
+	fn(entries[key])
+	entries[key].UpdatedAt = time.Now()
+	return save()
+}
+
+// List returns all entries, optionally restricted to one campaign, newest first
+func List(campaignID string) ([]Entry, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := load(); err != nil {
+		return nil, err
+	}
+
+	var list []Entry
+	for _, e := range entries {
+		if campaignID != "" && e.CampaignID != campaignID {
+			continue
+		}
+		list = append(list, *e)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].SentAt.After(list[j].SentAt)
+	})
+	return list, nil
+}
+
+// CountSentSince counts invitations sent for a campaign at or after since
+func CountSentSince(campaignID string, since time.Time) (int, error) {
+	list, err := List(campaignID)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, e := range list {
+		if !e.SentAt.Before(since) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// CountMessagedSince counts contacts of a campaign sent a follow-up message
+// at or after since
+func CountMessagedSince(campaignID string, since time.Time) (int, error) {
+	list, err := List(campaignID)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, e := range list {
+		if !e.LastMessageAt.IsZero() && !e.LastMessageAt.Before(since) {
+			n++
+		}
+	}
+	return n, nil
+}
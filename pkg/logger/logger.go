@@ -1,70 +1,92 @@
-package logger
-
-import (
-	"fmt"
-	"sync"
-)
-
-// handles broadcasting logs
-type Logger struct {
-	mu          sync.Mutex
-	subscribers []chan string
-}
-
-// creates a new Logger instance
-func New() *Logger {
-	return &Logger{
-		subscribers: make([]chan string, 0),
-	}
-}
-
-// logs a message to console and all subscribers
-func (l *Logger) Printf(format string, v ...interface{}) {
-	msg := fmt.Sprintf(format, v...)
-
-	fmt.Println(msg)
-
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	for _, ch := range l.subscribers {
-		select {
-		case ch <- msg:
-		default:
-		}
-	}
-}
-
-// Subscribe - a channel that receives log messages
-func (l *Logger) Subscribe() chan string {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	ch := make(chan string, 100)
-	l.subscribers = append(l.subscribers, ch)
-	return ch
-}
-
-// Unsubscribe removes a channel from the subscribers list
-func (l *Logger) Unsubscribe(ch chan string) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	for i, sub := range l.subscribers {
-		if sub == ch {
-			l.subscribers = append(l.subscribers[:i], l.subscribers[i+1:]...)
-			close(ch)
-			break
-		}
-	}
-}
-
-// Close closes all subscriber channels
-func (l *Logger) Close() {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	for _, ch := range l.subscribers {
-		close(ch)
-	}
-	l.subscribers = nil
-}
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Message is a log line, or a structured event when Event is set
+type Message struct {
+	Event string // event name, empty for plain log lines
+	Data  string // the line, or the event as JSON
+}
+
+// handles broadcasting logs
+type Logger struct {
+	mu          sync.Mutex
+	subscribers []chan Message
+}
+
+// creates a new Logger instance
+func New() *Logger {
+	return &Logger{
+		subscribers: make([]chan Message, 0),
+	}
+}
+
+// logs a message to console and all subscribers
+func (l *Logger) Printf(format string, v ...interface{}) {
+	msg := fmt.Sprintf(format, v...)
+
+	fmt.Println(msg)
+	l.broadcast(Message{Data: msg})
+}
+
+// Event logs a structured event; data is encoded as JSON
+func (l *Logger) Event(name string, data interface{}) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		l.Printf("Failed to encode %s event: %v", name, err)
+		return
+	}
+
+	fmt.Printf("[%s] %s\n", name, b)
+	l.broadcast(Message{Event: name, Data: string(b)})
+}
+
+func (l *Logger) broadcast(msg Message) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, ch := range l.subscribers {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+}
+
+// Subscribe - a channel that receives log messages
+func (l *Logger) Subscribe() chan Message {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ch := make(chan Message, 100)
+	l.subscribers = append(l.subscribers, ch)
+	return ch
+}
+
+// Unsubscribe removes a channel from the subscribers list
+func (l *Logger) Unsubscribe(ch chan Message) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, sub := range l.subscribers {
+		if sub == ch {
+			l.subscribers = append(l.subscribers[:i], l.subscribers[i+1:]...)
+			close(ch)
+			break
+		}
+	}
+}
+
+// Close closes all subscriber channels
+func (l *Logger) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, ch := range l.subscribers {
+		close(ch)
+	}
+	l.subscribers = nil
+}
@@ -0,0 +1,54 @@
+package note
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// MaxLength is LinkedIn's limit for an invitation note
+	MaxLength = 300
+	// MaxMessageLength is LinkedIn's limit for a direct message
+	MaxMessageLength = 8000
+)
+
+var placeholder = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+// Render substitutes {{name}} placeholders in tmpl with vars. Lookups are
+// case-insensitive; a placeholder without a value is an error, so we never
+// send a note with a raw "{{firstName}}" in it.
+func Render(tmpl string, vars map[string]string) (string, error) {
+	return render("note", tmpl, vars, MaxLength)
+}
+
+// RenderMessage renders a direct message template the same way as Render
+func RenderMessage(tmpl string, vars map[string]string) (string, error) {
+	return render("message", tmpl, vars, MaxMessageLength)
+}
+
+func render(kind, tmpl string, vars map[string]string, maxLength int) (string, error) {
+	lower := make(map[string]string, len(vars))
+	for k, v := range vars {
+		lower[strings.ToLower(k)] = v
+	}
+
+	var missing []string
+	out := placeholder.ReplaceAllStringFunc(tmpl, func(m string) string {
+		name := placeholder.FindStringSubmatch(m)[1]
+		v, ok := lower[strings.ToLower(name)]
+		if !ok || strings.TrimSpace(v) == "" {
+			missing = append(missing, name)
+			return m
+		}
+		return strings.TrimSpace(v)
+	})
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("%s template: no value for %s", kind, strings.Join(missing, ", "))
+	}
+	if len([]rune(out)) > maxLength {
+		return "", fmt.Errorf("%s is %d characters, limit is %d", kind, len([]rune(out)), maxLength)
+	}
+	return out, nil
+}
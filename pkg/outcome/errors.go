@@ -0,0 +1,60 @@
+package outcome
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+)
+
+var (
+	ErrRateLimited    = errors.New("rate limited by LinkedIn")
+	ErrSessionExpired = errors.New("LinkedIn session expired")
+)
+
+// NavigationError is a page that could not be loaded
+type NavigationError struct {
+	URL string
+	Err error
+}
+
+func (e *NavigationError) Error() string {
+	return fmt.Sprintf("navigate to %s: %v", e.URL, e.Err)
+}
+
+func (e *NavigationError) Unwrap() error {
+	return e.Err
+}
+
+// SelectorNotFound is a page element a step needed but could not find
+type SelectorNotFound struct {
+	Element string // what was looked for, e.g. "send button"
+}
+
+func (e *SelectorNotFound) Error() string {
+	return e.Element + " not found"
+}
+
+// PanicError is a panic recovered from a job, with the stack it was raised on
+type PanicError struct {
+	Value interface{}
+	Stack string
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Recover turns a panic into a *PanicError stored in *err. It must be
+// deferred directly, before the browser is closed by a later defer. Only
+// the panic is logged; the stack goes with the error into the run record.
+func Recover(err *error, log *logger.Logger) {
+	v := recover()
+	if v == nil {
+		return
+	}
+	p := &PanicError{Value: v, Stack: string(debug.Stack())}
+	log.Printf("Recovered from %v", p)
+	*err = p
+}
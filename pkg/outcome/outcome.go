@@ -0,0 +1,67 @@
+package outcome
+
+import "sort"
+
+// Outcome is what happened to one profile in a run or job
+type Outcome string
+
+const (
+	Sent            Outcome = "sent"
+	SentWithoutNote Outcome = "sent-without-note" // a note was asked for but could not be added
+	Unconfirmed     Outcome = "unconfirmed"       // Send was clicked but the invitation could not be confirmed
+	Skipped         Outcome = "skipped"
+	Failed          Outcome = "failed"
+	DryRun          Outcome = "dry-run"
+	Queued          Outcome = "queued" // held for manual review
+	Followed        Outcome = "followed"
+	Withdrawn       Outcome = "withdrawn"
+
+	// not sent because the note could not be added, under the skip and
+	// require note policies
+	NoteUnavailable Outcome = "note-unavailable"
+	NoteRequired    Outcome = "note-required"
+)
+
+// IsSent reports whether the invitation or message went out
+func (o Outcome) IsSent() bool {
+	return o == Sent || o == SentWithoutNote
+}
+
+// IsSkipped reports whether the profile was deliberately left alone
+func (o Outcome) IsSkipped() bool {
+	return o == Skipped || o == NoteUnavailable || o == Queued
+}
+
+// IsFailed reports whether something went wrong with the profile
+func (o Outcome) IsFailed() bool {
+	return o == Failed || o == NoteRequired
+}
+
+// Counts is the number of profiles per outcome
+type Counts map[Outcome]int
+
+func (c Counts) Sent() int    { return c.sum(Outcome.IsSent) }
+func (c Counts) Skipped() int { return c.sum(Outcome.IsSkipped) }
+func (c Counts) Failed() int  { return c.sum(Outcome.IsFailed) }
+
+func (c Counts) sum(match func(Outcome) bool) int {
+	n := 0
+	for o, count := range c {
+		if match(o) {
+			n += count
+		}
+	}
+	return n
+}
+
+// Sorted returns the outcomes with a count, in alphabetical order
+func (c Counts) Sorted() []Outcome {
+	var list []Outcome
+	for o, n := range c {
+		if n > 0 {
+			list = append(list, o)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	return list
+}
@@ -0,0 +1,165 @@
+package profileurl
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrEmpty       = errors.New("empty URL")
+	ErrNotLinkedIn = errors.New("not a linkedin.com URL")
+	ErrNotProfile  = errors.New("not a /in/ profile URL")
+)
+
+const base = "https://www.linkedin.com/in/"
+
+// member IDs are the opaque, case-sensitive URN identifiers LinkedIn uses in
+// search results, e.g. /in/ACoAABcdEfG... or urn:li:fs_miniProfile:ACoAA...
+var memberID = regexp.MustCompile(`^AC[a-zA-Z]AA[A-Za-z0-9_-]{8,}$`)
+
+// Identity is what we know about who a profile URL points at. Either part
+// may be empty; the same person can be reached by vanity name or member ID.
+type Identity struct {
+	Vanity   string `json:"vanity,omitempty"`
+	MemberID string `json:"memberId,omitempty"`
+}
+
+// Parse extracts the identity from any form of profile URL: relative hrefs,
+// locale or mobile subdomains, trailing slashes, query strings, percent-encoded
+// vanity names, member-ID URLs and sub-pages such as /overlay/contact-info/.
+func Parse(raw string) (Identity, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return Identity{}, ErrEmpty
+	}
+	if strings.HasPrefix(raw, "/") {
+		raw = "https://www.linkedin.com" + raw
+	} else if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return Identity{}, ErrNotProfile
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host != "linkedin.com" && !strings.HasSuffix(host, ".linkedin.com") {
+		return Identity{}, ErrNotLinkedIn
+	}
+
+	segments := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "in") {
+		return Identity{}, ErrNotProfile
+	}
+
+	name, err := url.PathUnescape(segments[1])
+	if err != nil || !utf8.ValidString(name) {
+		return Identity{}, ErrNotProfile
+	}
+	if !validName(name) {
+		return Identity{}, ErrNotProfile
+	}
+
+	var id Identity
+	if memberID.MatchString(name) {
+		id.MemberID = name
+	} else {
+		id.Vanity = strings.ToLower(name)
+	}
+
+	// search result links carry the member URN alongside the vanity name
+	if id.MemberID == "" {
+		if urn := u.Query().Get("miniProfileUrn"); urn != "" {
+			id.MemberID = memberFromURN(urn)
+		}
+	}
+	return id, nil
+}
+
+// vanity names are letters, digits and a little punctuation
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// memberFromURN returns the member ID from urn:li:fs_miniProfile:ACoAA... and similar
+func memberFromURN(urn string) string {
+	if decoded, err := url.QueryUnescape(urn); err == nil {
+		urn = decoded
+	}
+	i := strings.LastIndex(urn, ":")
+	candidate := strings.Trim(urn[i+1:], "()")
+	if memberID.MatchString(candidate) {
+		return candidate
+	}
+	return ""
+}
+
+// IsZero reports whether nothing identifies the profile
+func (id Identity) IsZero() bool {
+	return id.Vanity == "" && id.MemberID == ""
+}
+
+// URL is the canonical profile URL, preferring the vanity name
+func (id Identity) URL() string {
+	if id.Vanity != "" {
+		return base + url.PathEscape(id.Vanity)
+	}
+	if id.MemberID != "" {
+		return base + id.MemberID
+	}
+	return ""
+}
+
+// Matches reports whether both identities refer to the same member
+func (id Identity) Matches(other Identity) bool {
+	if id.Vanity != "" && id.Vanity == other.Vanity {
+		return true
+	}
+	return id.MemberID != "" && id.MemberID == other.MemberID
+}
+
+// Merge fills in whatever other knows that id does not
+func (id Identity) Merge(other Identity) Identity {
+	if id.Vanity == "" {
+		id.Vanity = other.Vanity
+	}
+	if id.MemberID == "" {
+		id.MemberID = other.MemberID
+	}
+	return id
+}
+
+// Canonical returns the canonical URL for raw
+func Canonical(raw string) (string, error) {
+	id, err := Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	return id.URL(), nil
+}
+
+// Same reports whether two URLs point at the same profile
+func Same(a, b string) bool {
+	ida, err := Parse(a)
+	if err != nil {
+		return false
+	}
+	idb, err := Parse(b)
+	if err != nil {
+		return false
+	}
+	return ida.Matches(idb)
+}
@@ -0,0 +1,116 @@
+package profileurl
+
+import (
+	"errors"
+	"testing"
+)
+
+const testMember = "ACoAABcdEfGhIjKlMnOp"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    Identity
+		wantErr error
+	}{
+		{raw: "https://www.linkedin.com/in/jane-doe", want: Identity{Vanity: "jane-doe"}},
+		{raw: "https://www.linkedin.com/in/jane-doe/", want: Identity{Vanity: "jane-doe"}},
+		{raw: "https://www.linkedin.com/in/Jane-Doe//", want: Identity{Vanity: "jane-doe"}},
+		{raw: "linkedin.com/in/jane-doe", want: Identity{Vanity: "jane-doe"}},
+		{raw: "/in/jane-doe?trk=people-search", want: Identity{Vanity: "jane-doe"}},
+		{raw: "  https://www.linkedin.com/in/jane-doe  ", want: Identity{Vanity: "jane-doe"}},
+		{raw: "https://de.linkedin.com/in/jane-doe", want: Identity{Vanity: "jane-doe"}},
+		{raw: "https://fr.linkedin.com/in/jane-doe/?locale=fr_FR", want: Identity{Vanity: "jane-doe"}},
+		{raw: "https://m.linkedin.com/in/jane-doe", want: Identity{Vanity: "jane-doe"}},
+		{raw: "http://LinkedIn.com/IN/jane-doe", want: Identity{Vanity: "jane-doe"}},
+		{raw: "https://www.linkedin.com/in/j%C3%BCrgen-m%C3%BCller", want: Identity{Vanity: "jürgen-müller"}},
+		{raw: "https://www.linkedin.com/in/J%C3%9CRGEN", want: Identity{Vanity: "jürgen"}},
+		{raw: "https://www.linkedin.com/in/" + testMember, want: Identity{MemberID: testMember}},
+		{raw: "https://www.linkedin.com/in/" + testMember + "/", want: Identity{MemberID: testMember}},
+		{raw: "https://www.linkedin.com/in/jane-doe/overlay/contact-info/", want: Identity{Vanity: "jane-doe"}},
+		{raw: "https://www.linkedin.com/in/jane-doe/details/experience/", want: Identity{Vanity: "jane-doe"}},
+		{
+			raw:  "https://www.linkedin.com/in/jane-doe?miniProfileUrn=urn%3Ali%3Afs_miniProfile%3A" + testMember,
+			want: Identity{Vanity: "jane-doe", MemberID: testMember},
+		},
+		{
+			raw:  "/in/jane-doe?miniProfileUrn=urn%253Ali%253Afs_miniProfile%253A" + testMember,
+			want: Identity{Vanity: "jane-doe", MemberID: testMember},
+		},
+		{raw: "https://www.linkedin.com/in/jane-doe?miniProfileUrn=garbage", want: Identity{Vanity: "jane-doe"}},
+
+		{raw: "", wantErr: ErrEmpty},
+		{raw: "   ", wantErr: ErrEmpty},
+		{raw: "https://example.com/in/jane-doe", wantErr: ErrNotLinkedIn},
+		{raw: "https://linkedin.com.evil.com/in/jane-doe", wantErr: ErrNotLinkedIn},
+		{raw: "https://www.linkedin.com/company/acme", wantErr: ErrNotProfile},
+		{raw: "https://www.linkedin.com/in/", wantErr: ErrNotProfile},
+		{raw: "https://www.linkedin.com/in/jane%20doe", wantErr: ErrNotProfile},
+		{raw: "https://www.linkedin.com/in/jane%zz", wantErr: ErrNotProfile},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := Parse(tt.raw)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.raw, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	tests := []struct{ raw, want string }{
+		{"https://de.linkedin.com/in/Jane-Doe/", "https://www.linkedin.com/in/jane-doe"},
+		{"https://www.linkedin.com/in/j%C3%BCrgen", "https://www.linkedin.com/in/j%C3%BCrgen"},
+		{"/in/" + testMember + "/overlay/contact-info/", "https://www.linkedin.com/in/" + testMember},
+	}
+	for _, tt := range tests {
+		got, err := Canonical(tt.raw)
+		if err != nil || got != tt.want {
+			t.Errorf("Canonical(%q) = %q, %v, want %q", tt.raw, got, err, tt.want)
+		}
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"https://www.linkedin.com/in/jane-doe/",
+		"https://de.linkedin.com/in/j%C3%BCrgen?trk=x",
+		"/in/" + testMember + "/overlay/contact-info/",
+		"linkedin.com/in/jane-doe?miniProfileUrn=urn%3Ali%3Afs_miniProfile%3A" + testMember,
+		"https://m.linkedin.com/IN/Jane.Doe_1",
+		"https://example.com/in/x",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, raw string) {
+		id, err := Parse(raw)
+		if err != nil {
+			return
+		}
+		if id.IsZero() {
+			t.Fatalf("Parse(%q) returned an empty identity", raw)
+		}
+
+		canonical := id.URL()
+		again, err := Parse(canonical)
+		if err != nil {
+			t.Fatalf("Parse(%q) of the canonical URL of %q: %v", canonical, raw, err)
+		}
+		if !again.Matches(id) {
+			t.Fatalf("Parse(%q) = %+v, does not match %+v from %q", canonical, again, id, raw)
+		}
+
+		once, err := Canonical(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		twice, err := Canonical(once)
+		if err != nil || twice != once {
+			t.Fatalf("Canonical not idempotent for %q: %q then %q, %v", raw, once, twice, err)
+		}
+	})
+}
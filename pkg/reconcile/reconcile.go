@@ -0,0 +1,188 @@
+package reconcile
+
+import (
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/actions"
+	"github.com/meetm/linkedin-automation-go/pkg/ledger"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/outcome"
+	"github.com/meetm/linkedin-automation-go/pkg/profileurl"
+	"github.com/meetm/linkedin-automation-go/pkg/workflow"
+	"github.com/meetm/linkedin-automation-go/utils"
+)
+
+const (
+	DefaultIgnoredAfter      = 21 * 24 * time.Hour
+	DefaultMaxVisits         = 20
+	DefaultConnectionScrolls = 5
+)
+
+type Options struct {
+	CampaignID string // empty reconciles every campaign
+	Headless   bool
+
+	// pending invitations older than this are marked ignored
+	IgnoredAfter time.Duration
+	// profiles missing from both lists are re-checked one by one, at most this many
+	MaxVisits int
+	// how far to scroll the connections list, newest first
+	ConnectionScrolls int
+}
+
+// Report counts the status each open invitation was found in
+type Report struct {
+	Checked    int `json:"checked"`
+	Changed    int `json:"changed"`
+	Accepted   int `json:"accepted"`
+	Pending    int `json:"pending"`
+	Ignored    int `json:"ignored"`
+	Withdrawn  int `json:"withdrawn"`
+	Unresolved int `json:"unresolved"`
+}
+
+func (o *Options) defaults() {
+	if o.IgnoredAfter <= 0 {
+		o.IgnoredAfter = DefaultIgnoredAfter
+	}
+	if o.MaxVisits < 0 {
+		o.MaxVisits = 0
+	} else if o.MaxVisits == 0 {
+		o.MaxVisits = DefaultMaxVisits
+	}
+	if o.ConnectionScrolls <= 0 {
+		o.ConnectionScrolls = DefaultConnectionScrolls
+	}
+}
+
+// Run checks every open invitation in the contact ledger against the Sent
+// Invitations manager and the connections list, re-visiting profiles that
+// appear in neither, and records what it finds in the ledger
+func Run(opts Options, log *logger.Logger) (report Report, err error) {
+	defer outcome.Recover(&err, log)
+	opts.defaults()
+
+	all, err := ledger.List(opts.CampaignID)
+	if err != nil {
+		return report, err
+	}
+	var open []ledger.Entry
+	for _, e := range all {
+		if e.Open() {
+			open = append(open, e)
+		}
+	}
+	if len(open) == 0 {
+		log.Printf("No open invitations to reconcile")
+		return report, nil
+	}
+	log.Printf("Reconciling %d open invitations...", len(open))
+
+	browser, page, err := workflow.Session(workflow.Config{Headless: opts.Headless}, log)
+	if err != nil {
+		return report, err
+	}
+	defer workflow.CloseBrowser(browser, log)
+
+	sent, err := actions.SentInvitations(page, log)
+	if err != nil {
+		return report, err
+	}
+	connections, err := actions.RecentConnections(page, opts.ConnectionScrolls, log)
+	if err != nil {
+		return report, err
+	}
+
+	visits := 0
+	for _, e := range open {
+		id := e.Identity()
+		now := time.Now()
+		status := ledger.Status("")
+
+		switch {
+		case matchesAny(connections, id):
+			status = ledger.StatusAccepted
+		case pendingIn(sent, id):
+			status = pendingStatus(e, now, opts.IgnoredAfter)
+		case visits < opts.MaxVisits:
+			if visits > 0 {
+				utils.LongRandomSleep(4, 8)
+			}
+			visits++
+			state, err := actions.CheckInvitation(page, e.ProfileURL, log)
+			if err != nil {
+				log.Printf("Could not check %s: %v", e.ProfileURL, err)
+				break
+			}
+			switch state {
+			case actions.InvitationAccepted:
+				status = ledger.StatusAccepted
+			case actions.InvitationPending:
+				status = pendingStatus(e, now, opts.IgnoredAfter)
+			case actions.InvitationGone:
+				status = ledger.StatusWithdrawn
+			}
+		}
+
+		report.Checked++
+		if status == "" {
+			report.Unresolved++
+			continue
+		}
+
+		changed, err := ledger.SetStatus(id, status, now)
+		if err != nil {
+			return report, err
+		}
+		if changed {
+			report.Changed++
+			log.Printf("%s: %s -> %s", displayName(e), e.Status, status)
+		}
+		switch status {
+		case ledger.StatusAccepted:
+			report.Accepted++
+		case ledger.StatusPending:
+			report.Pending++
+		case ledger.StatusIgnored:
+			report.Ignored++
+		case ledger.StatusWithdrawn:
+			report.Withdrawn++
+		}
+	}
+
+	log.Printf("Reconcile complete! Accepted: %d, Pending: %d, Ignored: %d, Withdrawn: %d, Unresolved: %d",
+		report.Accepted, report.Pending, report.Ignored, report.Withdrawn, report.Unresolved)
+	return report, nil
+}
+
+func pendingStatus(e ledger.Entry, now time.Time, ignoredAfter time.Duration) ledger.Status {
+	if now.Sub(e.SentAt) > ignoredAfter {
+		return ledger.StatusIgnored
+	}
+	return ledger.StatusPending
+}
+
+func matchesAny(ids []profileurl.Identity, id profileurl.Identity) bool {
+	for _, other := range ids {
+		if other.Matches(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func pendingIn(sent []actions.SentInvitation, id profileurl.Identity) bool {
+	for _, s := range sent {
+		if s.Identity.Matches(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func displayName(e ledger.Entry) string {
+	if e.Name != "" {
+		return e.Name
+	}
+	return e.ProfileURL
+}
@@ -0,0 +1,165 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/outcome"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/cdp"
+)
+
+// Policy is how often and how patiently a failing operation is retried.
+// Delays grow from Initial by Multiplier up to Max, each randomized by
+// +/- Jitter of itself.
+type Policy struct {
+	Attempts   int           // tries in total, including the first
+	Initial    time.Duration // delay after the first failure
+	Max        time.Duration // longest delay between tries
+	Multiplier float64
+	Jitter     float64       // 0..1
+	MaxElapsed time.Duration // no try starts after this much time, 0 for no limit
+
+	// Retryable classifies errors; nil means the package's Retryable
+	Retryable func(error) bool
+}
+
+var (
+	// Navigation covers page loads
+	Navigation = Policy{Attempts: 4, Initial: 2 * time.Second, Max: 15 * time.Second, Multiplier: 2, Jitter: 0.3, MaxElapsed: 90 * time.Second}
+
+	// Wait covers waiting for a loaded page to settle
+	Wait = Policy{Attempts: 3, Initial: time.Second, Max: 5 * time.Second, Multiplier: 2, Jitter: 0.3, MaxElapsed: 45 * time.Second}
+
+	// Launch covers starting the browser, where any failure is worth another try
+	Launch = Policy{Attempts: 3, Initial: 3 * time.Second, Max: 10 * time.Second, Multiplier: 2, Jitter: 0.2,
+		Retryable: func(error) bool { return true }}
+)
+
+// the clock and the wait between tries; tests replace them
+var (
+	now   = time.Now
+	sleep = func(ctx context.Context, d time.Duration) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(d):
+			return true
+		}
+	}
+)
+
+// permanent marks an error that must not be retried
+type permanent struct{ err error }
+
+func (p *permanent) Error() string { return p.err.Error() }
+func (p *permanent) Unwrap() error { return p.err }
+
+// Permanent wraps err so Do returns it without retrying
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanent{err}
+}
+
+// Do calls fn until it succeeds, fails with an error that is not retryable,
+// the policy runs out or ctx is done, and returns the last error. Retries are
+// logged as "<what> failed".
+func Do(ctx context.Context, p Policy, log *logger.Logger, what string, fn func() error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = Retryable
+	}
+
+	began := now()
+	delay := p.Initial
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		var perm *permanent
+		if errors.As(err, &perm) {
+			return perm.err
+		}
+		if !retryable(err) || attempt >= p.Attempts || ctx.Err() != nil {
+			return err
+		}
+
+		wait := jitter(delay, p.Jitter)
+		if p.MaxElapsed > 0 && now().Sub(began)+wait > p.MaxElapsed {
+			return err
+		}
+		log.Printf("%s failed (attempt %d/%d): %v, retrying in %s", what, attempt, p.Attempts, err, wait.Round(100*time.Millisecond))
+
+		if !sleep(ctx, wait) {
+			return err
+		}
+
+		delay = time.Duration(float64(delay) * p.Multiplier)
+		if p.Max > 0 && delay > p.Max {
+			delay = p.Max
+		}
+	}
+}
+
+func jitter(d time.Duration, frac float64) time.Duration {
+	if frac <= 0 || d <= 0 {
+		return d
+	}
+	return d + time.Duration((rand.Float64()*2-1)*frac*float64(d))
+}
+
+// transient DevTools errors seen while a page is navigating or reloading
+var transientCDP = []string{
+	"Execution context was destroyed",
+	"Cannot find context with specified id",
+	"Inspected target navigated or closed",
+	"Node with given id does not belong to the document",
+}
+
+// Retryable reports whether err is a transient failure: a timeout, a failed
+// page load, a network error or a page that changed under a DevTools call.
+// Rate limits, expired sessions and cancellations are never retried.
+func Retryable(err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, outcome.ErrRateLimited),
+		errors.Is(err, outcome.ErrSessionExpired):
+		return false
+	case errors.Is(err, context.DeadlineExceeded):
+		return true
+	}
+
+	var navErr *rod.NavigationError
+	if errors.As(err, &navErr) {
+		// the browser aborts a load when another one starts; that is ours
+		return navErr.Reason != "net::ERR_ABORTED"
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var cdpErr *cdp.Error
+	if errors.As(err, &cdpErr) {
+		for _, msg := range transientCDP {
+			if strings.Contains(cdpErr.Message, msg) {
+				return true
+			}
+		}
+	}
+	return false
+}
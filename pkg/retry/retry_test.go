@@ -0,0 +1,206 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/outcome"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/cdp"
+)
+
+// fakeTime replaces the clock and sleep with ones that only advance when
+// Do waits, and returns the waits seen
+func fakeTime(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var waits []time.Duration
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	origNow, origSleep := now, sleep
+	now = func() time.Time { return clock }
+	sleep = func(ctx context.Context, d time.Duration) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		waits = append(waits, d)
+		clock = clock.Add(d)
+		return true
+	}
+	t.Cleanup(func() { now, sleep = origNow, origSleep })
+	return &waits
+}
+
+var errTransient = context.DeadlineExceeded
+
+func TestDo(t *testing.T) {
+	errBroken := errors.New("broken")
+	steady := Policy{Attempts: 4, Initial: time.Second, Max: 4 * time.Second, Multiplier: 2}
+
+	tests := []struct {
+		name      string
+		policy    Policy
+		errs      []error // returned by successive calls, then nil
+		wantCalls int
+		wantErr   error
+		wantWaits []time.Duration
+	}{
+		{name: "first try", policy: steady, wantCalls: 1},
+		{
+			name:      "succeeds after retries",
+			policy:    steady,
+			errs:      []error{errTransient, errTransient},
+			wantCalls: 3,
+			wantWaits: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:      "attempts run out",
+			policy:    steady,
+			errs:      []error{errTransient, errTransient, errTransient, errTransient, errTransient},
+			wantCalls: 4,
+			wantErr:   errTransient,
+			wantWaits: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			name:      "delay capped at Max",
+			policy:    Policy{Attempts: 4, Initial: time.Second, Max: 3 * time.Second, Multiplier: 5},
+			errs:      []error{errTransient, errTransient, errTransient},
+			wantCalls: 4,
+			wantWaits: []time.Duration{time.Second, 3 * time.Second, 3 * time.Second},
+		},
+		{
+			name:      "not retryable",
+			policy:    steady,
+			errs:      []error{errBroken},
+			wantCalls: 1,
+			wantErr:   errBroken,
+		},
+		{
+			name:      "permanent",
+			policy:    Launch,
+			errs:      []error{Permanent(errBroken)},
+			wantCalls: 1,
+			wantErr:   errBroken,
+		},
+		{
+			name:      "custom classifier",
+			policy:    Policy{Attempts: 2, Initial: time.Second, Retryable: func(err error) bool { return err == errBroken }},
+			errs:      []error{errBroken, errTransient},
+			wantCalls: 2,
+			wantErr:   errTransient,
+			wantWaits: []time.Duration{time.Second},
+		},
+		{
+			name:      "no try starts after MaxElapsed",
+			policy:    Policy{Attempts: 10, Initial: 10 * time.Second, Multiplier: 2, MaxElapsed: 25 * time.Second},
+			errs:      []error{errTransient, errTransient, errTransient},
+			wantCalls: 2, // the second wait would end at 30s
+			wantErr:   errTransient,
+			wantWaits: []time.Duration{10 * time.Second},
+		},
+		{
+			name:      "try at exactly MaxElapsed",
+			policy:    Policy{Attempts: 10, Initial: 10 * time.Second, Multiplier: 1, MaxElapsed: 20 * time.Second},
+			errs:      []error{errTransient, errTransient, errTransient},
+			wantCalls: 3,
+			wantErr:   errTransient,
+			wantWaits: []time.Duration{10 * time.Second, 10 * time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			waits := fakeTime(t)
+			calls := 0
+			err := Do(context.Background(), tt.policy, logger.New(), "test", func() error {
+				calls++
+				if calls <= len(tt.errs) {
+					return tt.errs[calls-1]
+				}
+				return nil
+			})
+
+			if err != tt.wantErr {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if fmt.Sprint(*waits) != fmt.Sprint(tt.wantWaits) {
+				t.Errorf("waits = %v, want %v", *waits, tt.wantWaits)
+			}
+		})
+	}
+}
+
+func TestDoCancelled(t *testing.T) {
+	waits := fakeTime(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+	err := Do(ctx, Navigation, logger.New(), "test", func() error {
+		calls++
+		if calls == 2 {
+			cancel()
+		}
+		return errTransient
+	})
+	if err != errTransient || calls != 2 || len(*waits) != 1 {
+		t.Errorf("got %v after %d calls and %d waits, want %v after 2 calls and 1 wait", err, calls, len(*waits), errTransient)
+	}
+}
+
+func TestDoInterruptedWait(t *testing.T) {
+	origSleep := sleep
+	sleep = func(context.Context, time.Duration) bool { return false }
+	t.Cleanup(func() { sleep = origSleep })
+
+	calls := 0
+	err := Do(context.Background(), Navigation, logger.New(), "test", func() error {
+		calls++
+		return errTransient
+	})
+	if err != errTransient || calls != 1 {
+		t.Errorf("got %v after %d calls, want %v after 1", err, calls, errTransient)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if d := jitter(10*time.Second, 0.3); d < 7*time.Second || d > 13*time.Second {
+			t.Fatalf("jitter(10s, 0.3) = %s", d)
+		}
+	}
+	if d := jitter(10*time.Second, 0); d != 10*time.Second {
+		t.Errorf("jitter(10s, 0) = %s", d)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain error", errors.New("boom"), false},
+		{"canceled", context.Canceled, false},
+		{"deadline", context.DeadlineExceeded, true},
+		{"wrapped deadline", &outcome.NavigationError{URL: "https://www.linkedin.com/feed/", Err: context.DeadlineExceeded}, true},
+		{"rate limited", fmt.Errorf("send: %w", outcome.ErrRateLimited), false},
+		{"session expired", &outcome.NavigationError{URL: "https://www.linkedin.com/in/x", Err: outcome.ErrSessionExpired}, false},
+		{"failed load", &rod.NavigationError{Reason: "net::ERR_CONNECTION_RESET"}, true},
+		{"aborted load", &rod.NavigationError{Reason: "net::ERR_ABORTED"}, false},
+		{"network", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true},
+		{"page navigated under a call", &cdp.Error{Code: -32000, Message: "Execution context was destroyed."}, true},
+		{"other devtools error", &cdp.Error{Code: -32000, Message: "Could not find node with given id"}, false},
+	}
+	for _, tt := range tests {
+		if got := Retryable(tt.err); got != tt.want {
+			t.Errorf("%s: Retryable(%v) = %v, want %v", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
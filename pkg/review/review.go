@@ -0,0 +1,177 @@
+package review
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/storage"
+)
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+	StatusDone     Status = "done"
+)
+
+// what a queued item asks a human to approve
+const (
+	KindMessage = "message"
+	KindFollow  = "follow" // follow a profile that offers no Connect
+)
+
+var (
+	ErrNotFound = errors.New("review item not found")
+	ErrDecided  = errors.New("review item already decided")
+)
+
+// Item is an action held back until someone approves it
+type Item struct {
+	ID         string    `json:"id"`
+	Kind       string    `json:"kind"`
+	CampaignID string    `json:"campaignId,omitempty"`
+	ProfileURL string    `json:"profileUrl"`
+	Name       string    `json:"name,omitempty"`
+	Step       int       `json:"step"`           // follow-up step, counted from 0; 0 for follows
+	Text       string    `json:"text,omitempty"` // message to send, editable on approval
+	Status     Status    `json:"status"`
+	CreatedAt  time.Time `json:"createdAt"`
+	DecidedAt  time.Time `json:"decidedAt,omitzero"`
+	DoneAt     time.Time `json:"doneAt,omitzero"`
+}
+
+const queueFile = "review.json"
+
+var mu sync.Mutex
+
+func load() (map[string]*Item, error) {
+	items := make(map[string]*Item)
+	err := storage.ReadJSON(storage.Path(queueFile), &items)
+	if errors.Is(err, storage.ErrNotFound) {
+		return items, nil
+	}
+	return items, err
+}
+
+func save(items map[string]*Item) error {
+	return storage.WriteJSON(storage.Path(queueFile), items)
+}
+
+// Add queues an item for review unless the same kind and step is already
+// queued for the profile, and reports whether it was added
+func Add(it Item) (bool, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	items, err := load()
+	if err != nil {
+		return false, err
+	}
+	for _, existing := range items {
+		if existing.Kind == it.Kind && existing.ProfileURL == it.ProfileURL && existing.Step == it.Step {
+			return false, nil
+		}
+	}
+
+	it.ID = history.NewID()
+	it.Status = StatusPending
+	it.CreatedAt = time.Now()
+	items[it.ID] = &it
+	return true, save(items)
+}
+
+// List returns queued items of kind with status, oldest first; empty
+// arguments match everything
+func List(kind string, status Status) ([]Item, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	items, err := load()
+	if err != nil {
+		return nil, err
+	}
+	var list []Item
+	for _, it := range items {
+		if (kind == "" || it.Kind == kind) && (status == "" || it.Status == status) {
+			list = append(list, *it)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+	return list, nil
+}
+
+// Find returns the item of kind and step queued for a profile, if any
+func Find(kind, profileURL string, step int) (Item, bool, error) {
+	list, err := List(kind, "")
+	if err != nil {
+		return Item{}, false, err
+	}
+	for _, it := range list {
+		if it.ProfileURL == profileURL && it.Step == step {
+			return it, true, nil
+		}
+	}
+	return Item{}, false, nil
+}
+
+// Approve marks a pending item approved, replacing its text when text is not empty
+func Approve(id, text string) (Item, error) {
+	return decide(id, func(it *Item) {
+		it.Status = StatusApproved
+		if text != "" {
+			it.Text = text
+		}
+	})
+}
+
+// Reject marks a pending item rejected; it will not be acted on
+func Reject(id string) (Item, error) {
+	return decide(id, func(it *Item) {
+		it.Status = StatusRejected
+	})
+}
+
+func decide(id string, fn func(*Item)) (Item, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	items, err := load()
+	if err != nil {
+		return Item{}, err
+	}
+	it, ok := items[id]
+	if !ok {
+		return Item{}, ErrNotFound
+	}
+	if it.Status != StatusPending {
+		return *it, ErrDecided
+	}
+	fn(it)
+	it.DecidedAt = time.Now()
+	return *it, save(items)
+}
+
+// MarkDone records that an approved item has been carried out
+func MarkDone(id string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	items, err := load()
+	if err != nil {
+		return err
+	}
+	it, ok := items[id]
+	if !ok {
+		return ErrNotFound
+	}
+	it.Status = StatusDone
+	it.DoneAt = time.Now()
+	return save(items)
+}
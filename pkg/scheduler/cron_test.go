@@ -0,0 +1,181 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr bool
+	}{
+		{spec: "30 9 * * 1-5"},
+		{spec: "*/15 9-16 * * mon-fri"},
+		{spec: "0 0 1,15 * *"},
+		{spec: "0 12 * 1-12/3 sun"},
+		{spec: "5-55/10 * * * 7"},
+		{spec: "0 9 * * MON,Wed"},
+		{spec: "0 9 * *", wantErr: true},
+		{spec: "0 9 * * * *", wantErr: true},
+		{spec: "60 9 * * *", wantErr: true},
+		{spec: "0 24 * * *", wantErr: true},
+		{spec: "0 9 0 * *", wantErr: true},
+		{spec: "0 9 * 13 *", wantErr: true},
+		{spec: "0 9 * * 8", wantErr: true},
+		{spec: "0 17-9 * * *", wantErr: true},
+		{spec: "*/0 * * * *", wantErr: true},
+		{spec: "*/x * * * *", wantErr: true},
+		{spec: "0 9 * * funday", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := ParseCron(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCron(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	utc := time.UTC
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	santiago, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	est := time.FixedZone("EST", -5*3600)
+	edt := time.FixedZone("EDT", -4*3600)
+
+	tests := []struct {
+		name string
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "later the same day",
+			spec: "30 9 * * *",
+			from: time.Date(2026, 1, 5, 8, 0, 0, 0, utc),
+			want: time.Date(2026, 1, 5, 9, 30, 0, 0, utc),
+		},
+		{
+			name: "strictly after",
+			spec: "30 9 * * *",
+			from: time.Date(2026, 1, 5, 9, 30, 0, 0, utc),
+			want: time.Date(2026, 1, 6, 9, 30, 0, 0, utc),
+		},
+		{
+			name: "seconds are truncated",
+			spec: "* * * * *",
+			from: time.Date(2026, 1, 5, 9, 30, 59, 0, utc),
+			want: time.Date(2026, 1, 5, 9, 31, 0, 0, utc),
+		},
+		{
+			name: "weekday range skips the weekend",
+			spec: "0 9 * * 1-5",
+			from: time.Date(2026, 1, 9, 10, 0, 0, 0, utc), // Friday
+			want: time.Date(2026, 1, 12, 9, 0, 0, 0, utc),
+		},
+		{
+			name: "minute step within an hour range",
+			spec: "*/15 9-16 * * mon-fri",
+			from: time.Date(2026, 1, 5, 9, 46, 0, 0, utc),
+			want: time.Date(2026, 1, 5, 10, 0, 0, 0, utc),
+		},
+		{
+			name: "end of an hour range",
+			spec: "*/15 9-16 * * mon-fri",
+			from: time.Date(2026, 1, 5, 16, 45, 0, 0, utc),
+			want: time.Date(2026, 1, 6, 9, 0, 0, 0, utc),
+		},
+		{
+			name: "step from a start value",
+			spec: "10/20 * * * *",
+			from: time.Date(2026, 1, 5, 9, 31, 0, 0, utc),
+			want: time.Date(2026, 1, 5, 9, 50, 0, 0, utc),
+		},
+		{
+			name: "sunday as 7",
+			spec: "0 9 * * 7",
+			from: time.Date(2026, 1, 5, 0, 0, 0, 0, utc),
+			want: time.Date(2026, 1, 11, 9, 0, 0, 0, utc),
+		},
+		{
+			name: "day of month or day of week",
+			spec: "0 9 13 * fri",
+			from: time.Date(2026, 1, 10, 0, 0, 0, 0, utc),
+			want: time.Date(2026, 1, 13, 9, 0, 0, 0, utc),
+		},
+		{
+			name: "month rollover",
+			spec: "0 0 1 * *",
+			from: time.Date(2026, 12, 15, 0, 0, 0, 0, utc),
+			want: time.Date(2027, 1, 1, 0, 0, 0, 0, utc),
+		},
+		{
+			name: "leap day",
+			spec: "0 0 29 2 *",
+			from: time.Date(2026, 3, 1, 0, 0, 0, 0, utc),
+			want: time.Date(2028, 2, 29, 0, 0, 0, 0, utc),
+		},
+		{
+			name: "impossible date",
+			spec: "0 0 31 2 *",
+			from: time.Date(2026, 1, 1, 0, 0, 0, 0, utc),
+			want: time.Time{},
+		},
+		{
+			name: "local hour across spring forward",
+			spec: "0 9 * * *",
+			from: time.Date(2026, 3, 7, 9, 0, 0, 0, ny),
+			want: time.Date(2026, 3, 8, 9, 0, 0, 0, edt),
+		},
+		{
+			name: "skipped local time when clocks go forward",
+			spec: "30 2 * * *",
+			from: time.Date(2026, 3, 8, 1, 0, 0, 0, ny),
+			want: time.Date(2026, 3, 9, 2, 30, 0, 0, edt),
+		},
+		{
+			name: "skipped midnight",
+			spec: "0 9 * * *",
+			from: time.Date(2026, 9, 5, 10, 0, 0, 0, santiago),
+			want: time.Date(2026, 9, 6, 9, 0, 0, 0, time.FixedZone("-03", -3*3600)),
+		},
+		{
+			name: "repeated local time when clocks go back",
+			spec: "30 1 * * *",
+			from: time.Date(2026, 11, 1, 0, 0, 0, 0, ny),
+			want: time.Date(2026, 11, 1, 1, 30, 0, 0, edt),
+		},
+		{
+			name: "repeated local time matches once",
+			spec: "30 1 * * *",
+			from: time.Date(2026, 11, 1, 1, 30, 0, 0, edt).In(ny),
+			want: time.Date(2026, 11, 2, 1, 30, 0, 0, est),
+		},
+		{
+			name: "every minute through the repeated hour",
+			spec: "* * * * *",
+			from: time.Date(2026, 11, 1, 1, 59, 0, 0, edt).In(ny),
+			want: time.Date(2026, 11, 1, 2, 0, 0, 0, est),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseCron(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := c.Next(tt.from)
+			if !got.Equal(tt.want) {
+				t.Errorf("Next(%s) = %s, want %s", tt.from, got, tt.want)
+			}
+		})
+	}
+}
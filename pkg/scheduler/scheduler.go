@@ -0,0 +1,290 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/outcome"
+	"github.com/meetm/linkedin-automation-go/pkg/storage"
+	"github.com/meetm/linkedin-automation-go/pkg/workflow"
+)
+
+var (
+	ErrOutsideWindow  = errors.New("outside the allowed time window")
+	ErrQuotaExhausted = errors.New("daily quota exhausted")
+	ErrBusy           = errors.New("another scheduled run is in progress")
+)
+
+const (
+	stateFile  = "scheduler-state.json"
+	dateLayout = "2006-01-02"
+)
+
+// Spec describes when and how much a campaign may run
+type Spec struct {
+	Enabled    bool   `json:"enabled"`
+	Cron       string `json:"cron"`
+	TimeZone   string `json:"timezone"`
+	Window     Window `json:"window"`
+	DailyQuota int    `json:"dailyQuota"`
+	WeeklyCap  int    `json:"weeklyCap,omitempty"`
+}
+
+// Entry is a named campaign run on a schedule
+type Entry struct {
+	Name   string
+	Spec   Spec
+	Config workflow.Config
+}
+
+// State is what the scheduler remembers about an entry across restarts
+type State struct {
+	LastSlot  time.Time      `json:"lastSlot"`
+	LastRun   time.Time      `json:"lastRun,omitzero"`
+	LastRunID string         `json:"lastRunId,omitempty"`
+	Used      map[string]int `json:"used"`
+}
+
+func (s Spec) Validate() error {
+	if _, err := ParseCron(s.Cron); err != nil {
+		return err
+	}
+	if _, err := s.Location(); err != nil {
+		return err
+	}
+	if err := s.Window.Validate(); err != nil {
+		return err
+	}
+	if s.DailyQuota <= 0 {
+		return errors.New("dailyQuota must be positive")
+	}
+	if s.WeeklyCap < 0 {
+		return errors.New("weeklyCap must not be negative")
+	}
+	return nil
+}
+
+func (s Spec) Location() (*time.Location, error) {
+	if s.TimeZone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", s.TimeZone)
+	}
+	return loc, nil
+}
+
+// Allowance returns how many requests may still be sent on now's local day.
+// Unused quota from earlier days of the same week carries over, but never
+// beyond the weekly cap. Without a weekly cap there is no carry-over.
+func (s Spec) Allowance(st *State, now time.Time) int {
+	loc, err := s.Location()
+	if err != nil {
+		return 0
+	}
+	now = now.In(loc)
+	today := now.Format(dateLayout)
+
+	if s.WeeklyCap == 0 {
+		return max(0, s.DailyQuota-st.Used[today])
+	}
+
+	offset := (int(now.Weekday()) + 6) % 7 // days since Monday
+	weekStart := time.Date(now.Year(), now.Month(), now.Day()-offset, 0, 0, 0, 0, loc)
+
+	allowedDays, usedWeek := 0, 0
+	for d := weekStart; d.Format(dateLayout) <= today; d = d.AddDate(0, 0, 1) {
+		if s.Window.AllowsDay(d) {
+			allowedDays++
+		}
+		usedWeek += st.Used[d.Format(dateLayout)]
+	}
+
+	accrued := s.DailyQuota*allowedDays - usedWeek
+	return max(0, min(accrued, s.WeeklyCap-usedWeek))
+}
+
+type Scheduler struct {
+	Log     *logger.Logger
+	Entries func() ([]Entry, error)
+
+	mu      sync.Mutex
+	running bool
+	state   map[string]*State
+}
+
+// New creates a scheduler that polls entries for the campaigns to run
+func New(log *logger.Logger, entries func() ([]Entry, error)) *Scheduler {
+	s := &Scheduler{Log: log, Entries: entries, state: make(map[string]*State)}
+	if err := storage.ReadJSON(storage.Path(stateFile), &s.state); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		log.Printf("Scheduler: could not read state: %v", err)
+	}
+	return s
+}
+
+// Start checks all entries every interval until ctx is cancelled
+func (s *Scheduler) Start(ctx context.Context, interval time.Duration) {
+	s.Log.Printf("Scheduler started")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.tick(time.Now())
+		select {
+		case <-ctx.Done():
+			s.Log.Printf("Scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) tick(now time.Time) {
+	entries, err := s.Entries()
+	if err != nil {
+		s.Log.Printf("Scheduler: %v", err)
+		return
+	}
+
+	for _, e := range entries {
+		if !e.Spec.Enabled {
+			continue
+		}
+		if !s.due(e, now) {
+			continue
+		}
+		if err := s.Trigger(e, now); err != nil {
+			s.Log.Printf("Scheduler: %s not started: %v", e.Name, err)
+		}
+	}
+}
+
+// due reports whether a cron slot for e has passed since the last one handled,
+// and marks it handled so a missed slot is caught up at most once after a restart
+func (s *Scheduler) due(e Entry, now time.Time) bool {
+	cron, _ := ParseCron(e.Spec.Cron)
+	loc, _ := e.Spec.Location()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.entryState(e.Name)
+	if st.LastSlot.IsZero() {
+		st.LastSlot = now
+		s.save()
+		return false
+	}
+
+	next := cron.Next(st.LastSlot.In(loc))
+	if next.IsZero() || next.After(now) {
+		return false
+	}
+	for n := next; !n.IsZero() && !n.After(now); n = cron.Next(n) {
+		next = n
+	}
+	st.LastSlot = next
+	s.save()
+	return true
+}
+
+// Trigger runs e immediately if now is inside its window and quota remains
+func (s *Scheduler) Trigger(e Entry, now time.Time) error {
+	loc, err := e.Spec.Location()
+	if err != nil {
+		return err
+	}
+	local := now.In(loc)
+	if !e.Spec.Window.Contains(local) {
+		return fmt.Errorf("%w (%s-%s %s, now %s)", ErrOutsideWindow,
+			e.Spec.Window.Start, e.Spec.Window.End, loc, local.Format("Mon 15:04"))
+	}
+
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return ErrBusy
+	}
+	st := s.entryState(e.Name)
+	allowance := e.Spec.Allowance(st, now)
+	if allowance == 0 {
+		s.mu.Unlock()
+		return ErrQuotaExhausted
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.running = false
+		s.mu.Unlock()
+	}()
+
+	cfg := e.Config
+	cfg.RunID = history.NewID()
+	if cfg.Limit <= 0 || cfg.Limit > allowance {
+		cfg.Limit = allowance
+	}
+
+	s.Log.Printf("Scheduler: starting %s (limit %d)", e.Name, cfg.Limit)
+	stats, err := workflow.Run(cfg, s.Log)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st.LastRun = now
+	st.LastRunID = cfg.RunID
+	// an unconfirmed invitation may well have gone out, so it uses up quota
+	st.Used[local.Format(dateLayout)] += stats.Outcomes.Sent() + stats.Outcomes[outcome.Unconfirmed]
+	s.prune(st, local)
+	s.save()
+
+	return err
+}
+
+// Status returns a copy of the persisted state for an entry
+func (s *Scheduler) Status(name string) State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.entryState(name)
+	cp := *st
+	cp.Used = make(map[string]int, len(st.Used))
+	for k, v := range st.Used {
+		cp.Used[k] = v
+	}
+	return cp
+}
+
+func (s *Scheduler) entryState(name string) *State {
+	st, ok := s.state[name]
+	if !ok {
+		st = &State{}
+		s.state[name] = st
+	}
+	if st.Used == nil {
+		st.Used = make(map[string]int)
+	}
+	return st
+}
+
+// prune drops usage older than two weeks
+func (s *Scheduler) prune(st *State, now time.Time) {
+	cutoff := now.AddDate(0, 0, -14).Format(dateLayout)
+	for day := range st.Used {
+		if day < cutoff {
+			delete(st.Used, day)
+		}
+	}
+}
+
+func (s *Scheduler) save() {
+	if err := storage.WriteJSON(storage.Path(stateFile), s.state); err != nil {
+		s.Log.Printf("Scheduler: could not save state: %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSpecAllowance(t *testing.T) {
+	// the week of Monday 2026-01-05
+	wed := time.Date(2026, 1, 7, 12, 0, 0, 0, time.UTC)
+	sat := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	nextMon := time.Date(2026, 1, 12, 12, 0, 0, 0, time.UTC)
+	allDays := Window{Start: "00:00", End: "23:59", Days: []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}}
+
+	tests := []struct {
+		name string
+		spec Spec
+		used map[string]int
+		now  time.Time
+		want int
+	}{
+		{name: "daily quota", spec: Spec{DailyQuota: 20}, now: wed, want: 20},
+		{name: "part used today", spec: Spec{DailyQuota: 20}, used: map[string]int{"2026-01-07": 5}, now: wed, want: 15},
+		{name: "overused today", spec: Spec{DailyQuota: 20}, used: map[string]int{"2026-01-07": 25}, now: wed, want: 0},
+		{name: "no carry-over without a weekly cap", spec: Spec{DailyQuota: 20}, used: map[string]int{"2026-01-05": 0}, now: wed, want: 20},
+		{name: "carry-over from earlier weekdays", spec: Spec{DailyQuota: 20, WeeklyCap: 100}, now: wed, want: 60},
+		{name: "carry-over limited by the weekly cap", spec: Spec{DailyQuota: 20, WeeklyCap: 50}, now: wed, want: 50},
+		{
+			name: "carry-over less what was used",
+			spec: Spec{DailyQuota: 20, WeeklyCap: 100},
+			used: map[string]int{"2026-01-05": 20, "2026-01-06": 20, "2026-01-07": 10},
+			now:  wed,
+			want: 10,
+		},
+		{
+			name: "weekly cap reached",
+			spec: Spec{DailyQuota: 40, WeeklyCap: 100},
+			used: map[string]int{"2026-01-05": 40, "2026-01-06": 40, "2026-01-07": 20},
+			now:  wed,
+			want: 0,
+		},
+		{
+			name: "days outside the window accrue nothing",
+			spec: Spec{DailyQuota: 20, WeeklyCap: 200},
+			used: map[string]int{"2026-01-05": 20, "2026-01-06": 20, "2026-01-07": 20, "2026-01-08": 20},
+			now:  sat,
+			want: 20,
+		},
+		{
+			name: "configured window days",
+			spec: Spec{DailyQuota: 20, WeeklyCap: 200, Window: Window{Start: "09:00", End: "17:00", Days: []string{"mon", "sat"}}},
+			now:  sat,
+			want: 40,
+		},
+		{
+			name: "a new week starts on monday",
+			spec: Spec{DailyQuota: 20, WeeklyCap: 100},
+			used: map[string]int{"2026-01-05": 20, "2026-01-06": 20, "2026-01-07": 20, "2026-01-08": 20, "2026-01-09": 20},
+			now:  nextMon,
+			want: 20,
+		},
+		{
+			name: "the day is taken in the spec's time zone",
+			spec: Spec{DailyQuota: 20, TimeZone: "America/Los_Angeles"},
+			used: map[string]int{"2026-01-06": 20},
+			now:  time.Date(2026, 1, 7, 3, 0, 0, 0, time.UTC), // Tuesday evening in Los Angeles
+			want: 0,
+		},
+		{
+			name: "week across the start of daylight saving time",
+			spec: Spec{DailyQuota: 10, WeeklyCap: 1000, TimeZone: "America/New_York", Window: allDays},
+			now:  time.Date(2026, 3, 9, 3, 30, 0, 0, time.UTC), // Sunday 2026-03-08 23:30 EDT
+			want: 70,
+		},
+		{
+			name: "week across the end of daylight saving time",
+			spec: Spec{DailyQuota: 10, WeeklyCap: 1000, TimeZone: "America/New_York", Window: allDays},
+			used: map[string]int{"2026-11-01": 5},
+			now:  time.Date(2026, 11, 2, 4, 30, 0, 0, time.UTC), // Sunday 2026-11-01 23:30 EST
+			want: 65,
+		},
+		{name: "unknown time zone", spec: Spec{DailyQuota: 20, TimeZone: "Mars/Olympus"}, now: wed, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &State{Used: tt.used}
+			if got := tt.spec.Allowance(st, tt.now); got != tt.want {
+				t.Errorf("Allowance() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
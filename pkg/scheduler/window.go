@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Window is the local time range in which a campaign may run, e.g. 09:00-17:00 on weekdays
+type Window struct {
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+	Days  []string `json:"days,omitempty"`
+}
+
+var weekdays = []string{"mon", "tue", "wed", "thu", "fri"}
+
+func (w Window) Validate() error {
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return fmt.Errorf("window start: %w", err)
+	}
+	end, err := parseClock(w.End)
+	if err != nil {
+		return fmt.Errorf("window end: %w", err)
+	}
+	if end <= start {
+		return fmt.Errorf("window end %s must be after start %s", w.End, w.Start)
+	}
+	for _, d := range w.Days {
+		if _, ok := dayNames[strings.ToLower(d)]; !ok {
+			return fmt.Errorf("window: unknown day %q", d)
+		}
+	}
+	return nil
+}
+
+// AllowsDay reports whether runs are permitted at all on t's weekday
+func (w Window) AllowsDay(t time.Time) bool {
+	days := w.Days
+	if len(days) == 0 {
+		days = weekdays
+	}
+	for _, d := range days {
+		if dayNames[strings.ToLower(d)] == int(t.Weekday()) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether t, already in the campaign's location, falls inside the window
+func (w Window) Contains(t time.Time) bool {
+	if !w.AllowsDay(t) {
+		return false
+	}
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(w.End)
+	if err != nil {
+		return false
+	}
+	minutes := t.Hour()*60 + t.Minute()
+	return minutes >= start && minutes < end
+}
+
+// parseClock converts "HH:MM" to minutes after midnight
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
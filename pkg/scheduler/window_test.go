@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWindowContains(t *testing.T) {
+	office := Window{Start: "09:00", End: "17:00"}
+	weekend := Window{Start: "10:00", End: "12:30", Days: []string{"SAT", "sun"}}
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2026, 1, day, hour, min, 0, 0, time.UTC) // 2026-01-05 is a Monday
+	}
+
+	tests := []struct {
+		name string
+		w    Window
+		t    time.Time
+		want bool
+	}{
+		{name: "before start", w: office, t: at(5, 8, 59), want: false},
+		{name: "at start", w: office, t: at(5, 9, 0), want: true},
+		{name: "last minute", w: office, t: at(5, 16, 59), want: true},
+		{name: "at end", w: office, t: at(5, 17, 0), want: false},
+		{name: "weekdays by default", w: office, t: at(9, 12, 0), want: true},
+		{name: "no weekends by default", w: office, t: at(10, 12, 0), want: false},
+		{name: "configured days", w: weekend, t: at(11, 12, 29), want: true},
+		{name: "configured days exclude others", w: weekend, t: at(5, 11, 0), want: false},
+		{name: "half-hour end", w: weekend, t: at(10, 12, 30), want: false},
+		{name: "invalid window", w: Window{Start: "9am", End: "17:00"}, t: at(5, 12, 0), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.w.Contains(tt.t); got != tt.want {
+				t.Errorf("Contains(%s) = %v, want %v", tt.t.Format("Mon 15:04"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWindowValidate(t *testing.T) {
+	tests := []struct {
+		w       Window
+		wantErr bool
+	}{
+		{w: Window{Start: "09:00", End: "17:00"}},
+		{w: Window{Start: "00:00", End: "23:59", Days: []string{"Mon", "sun"}}},
+		{w: Window{Start: "17:00", End: "09:00"}, wantErr: true},
+		{w: Window{Start: "09:00", End: "09:00"}, wantErr: true},
+		{w: Window{Start: "9", End: "17:00"}, wantErr: true},
+		{w: Window{Start: "09:00", End: "24:00"}, wantErr: true},
+		{w: Window{Start: "09:00", End: "17:00", Days: []string{"monday"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := tt.w.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%+v) error = %v, wantErr %v", tt.w, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned when a stored document does not exist
+	ErrNotFound = errors.New("not found")
+
+	// ErrInvalidName is returned for a name that could reach outside the
+	// directory it is joined onto
+	ErrInvalidName = errors.New("invalid name")
+)
+
+// CheckName rejects names, such as IDs taken from a URL, that are empty or
+// contain a path separator or ".."
+func CheckName(name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") {
+		return fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	return nil
+}
+
+// Dir returns the directory holding persisted state, creating it if needed.
+// LINKEDIN_DATA_DIR overrides the default of ~/.linkedin-automation.
+func Dir() string {
+	dir := os.Getenv("LINKEDIN_DATA_DIR")
+	if dir == "" {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".linkedin-automation")
+	}
+	os.MkdirAll(dir, 0700)
+	return dir
+}
+
+// Path joins name onto the data directory, creating parent directories
+func Path(name ...string) string {
+	p := filepath.Join(append([]string{Dir()}, name...)...)
+	os.MkdirAll(filepath.Dir(p), 0700)
+	return p
+}
+
+// ReadJSON decodes the document at path into v
+func ReadJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// WriteJSON atomically replaces the document at path with v
+func WriteJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Remove deletes the document at path; a missing document is not an error
+func Remove(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package vault
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/meetm/linkedin-automation-go/pkg/storage"
+)
+
+// TOTPSecret is the base32 secret of the account's authenticator app, as
+// shown when setting it up
+const TOTPSecret = "totp-secret"
+
+var ErrNotFound = errors.New("secret not found in vault")
+
+// secrets live in a file readable only by the current user
+const vaultFile = "vault.json"
+
+var mu sync.Mutex
+
+func load() (map[string]string, error) {
+	secrets := map[string]string{}
+	err := storage.ReadJSON(storage.Path(vaultFile), &secrets)
+	if errors.Is(err, storage.ErrNotFound) {
+		return secrets, nil
+	}
+	return secrets, err
+}
+
+// Get returns the secret stored under name
+func Get(name string) (string, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	secrets, err := load()
+	if err != nil {
+		return "", err
+	}
+	value, ok := secrets[name]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+// Set stores value under name, replacing any previous one
+func Set(name, value string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	secrets, err := load()
+	if err != nil {
+		return err
+	}
+	secrets[name] = value
+	return storage.WriteJSON(storage.Path(vaultFile), secrets)
+}
+
+// Delete removes the secret stored under name
+func Delete(name string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	secrets, err := load()
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[name]; !ok {
+		return ErrNotFound
+	}
+	delete(secrets, name)
+	return storage.WriteJSON(storage.Path(vaultFile), secrets)
+}
+
+// Names lists the stored secrets, never their values
+func Names() ([]string, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	secrets, err := load()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(secrets))
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,192 @@
+package withdraw
+
+import (
+	"errors"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/actions"
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/ledger"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/outcome"
+	"github.com/meetm/linkedin-automation-go/pkg/workflow"
+	"github.com/meetm/linkedin-automation-go/utils"
+)
+
+const (
+	DefaultOlderThan = 30 * 24 * time.Hour
+	DefaultLimit     = 20
+	DefaultDailyCap  = 50
+)
+
+type Options struct {
+	RunID      string
+	CampaignID string // only invitations the ledger records for this campaign
+	OlderThan  time.Duration
+	Limit      int // withdrawals per job
+	DailyCap   int // withdrawals per day across jobs
+	DryRun     bool
+	Headless   bool
+}
+
+func (o *Options) defaults() {
+	if o.RunID == "" {
+		o.RunID = history.NewID()
+	}
+	if o.OlderThan <= 0 {
+		o.OlderThan = DefaultOlderThan
+	}
+	if o.Limit <= 0 {
+		o.Limit = DefaultLimit
+	}
+	if o.DailyCap <= 0 {
+		o.DailyCap = DefaultDailyCap
+	}
+}
+
+// Run withdraws pending invitations older than opts.OlderThan from the Sent
+// Invitations manager. The job is recorded in the run history like any run,
+// with one result per invitation, and withdrawn invitations are marked in
+// the contact ledger.
+func Run(opts Options, log *logger.Logger) (*history.Record, error) {
+	opts.defaults()
+
+	rec := &history.Record{
+		ID:         opts.RunID,
+		Kind:       history.KindWithdraw,
+		CampaignID: opts.CampaignID,
+		Limit:      opts.Limit,
+		DryRun:     opts.DryRun,
+		Status:     history.StatusRunning,
+		StartedAt:  time.Now(),
+	}
+	save(rec, log)
+
+	err := run(opts, rec, log)
+
+	rec.FinishedAt = time.Now()
+	if err != nil {
+		rec.Fail(err)
+	} else {
+		rec.Status = history.StatusCompleted
+	}
+	save(rec, log)
+	return rec, err
+}
+
+func run(opts Options, rec *history.Record, log *logger.Logger) (err error) {
+	defer outcome.Recover(&err, log)
+	browser, page, err := workflow.Session(workflow.Config{Headless: opts.Headless}, log)
+	if err != nil {
+		return err
+	}
+	defer workflow.CloseBrowser(browser, log)
+
+	sent, err := actions.SentInvitations(page, log)
+	if err != nil {
+		return err
+	}
+
+	var stale []actions.SentInvitation
+	for _, inv := range sent {
+		entry, inLedger, err := ledger.Find(inv.Identity)
+		if err != nil {
+			return err
+		}
+		if opts.CampaignID != "" && (!inLedger || entry.CampaignID != opts.CampaignID) {
+			continue
+		}
+
+		age, known := actions.ParseAge(inv.Sent)
+		if inLedger && !entry.SentAt.IsZero() {
+			age, known = time.Since(entry.SentAt), true
+		}
+		if known && age >= opts.OlderThan {
+			stale = append(stale, inv)
+		}
+	}
+	rec.ProfilesFound = len(stale)
+	log.Printf("Found %d invitations older than %d days", len(stale), int(opts.OlderThan.Hours()/24))
+	if len(stale) > opts.Limit {
+		stale = stale[:opts.Limit]
+	}
+
+	for i, inv := range stale {
+		if capReached(opts, log) {
+			break
+		}
+
+		res := history.Result{ProfileURL: inv.Identity.URL(), Name: inv.Name, Reason: inv.Sent}
+		if opts.DryRun {
+			log.Printf("Dry run: would withdraw %s (%s)", res.ProfileURL, inv.Sent)
+			res.Outcome = outcome.DryRun
+			rec.Count(res.Outcome)
+			rec.Add(res)
+			continue
+		}
+
+		if err := actions.WithdrawInvitation(page, inv.Identity, log); err != nil {
+			log.Printf("Failed to withdraw %s: %v", res.ProfileURL, err)
+			res.Outcome = outcome.Failed
+			res.Reason = err.Error()
+		} else {
+			log.Printf("Withdrawn: %s", res.ProfileURL)
+			res.Outcome = outcome.Withdrawn
+			markWithdrawn(inv, log)
+		}
+		rec.Count(res.Outcome)
+		rec.Add(res)
+		save(rec, log)
+
+		if i < len(stale)-1 {
+			utils.LongRandomSleep(4, 9)
+		}
+	}
+
+	log.Printf("Withdraw complete! Withdrawn: %d, Failed: %d", rec.Withdrawn, rec.Failed)
+	return nil
+}
+
+// markWithdrawn updates the ledger, adding invitations sent outside this
+// tool so they are not sent again either
+func markWithdrawn(inv actions.SentInvitation, log *logger.Logger) {
+	now := time.Now()
+	_, err := ledger.SetStatus(inv.Identity, ledger.StatusWithdrawn, now)
+	if errors.Is(err, ledger.ErrNotFound) {
+		err = ledger.Put(ledger.Entry{
+			ProfileURL:  inv.Identity.URL(),
+			Vanity:      inv.Identity.Vanity,
+			MemberID:    inv.Identity.MemberID,
+			Name:        inv.Name,
+			Status:      ledger.StatusWithdrawn,
+			SentAt:      now.Add(-inv.Age),
+			CheckedAt:   now,
+			WithdrawnAt: now,
+		})
+	}
+	if err != nil {
+		log.Printf("Failed to update contact ledger: %v", err)
+	}
+}
+
+// capReached reports whether the daily withdrawal cap has been used up
+func capReached(opts Options, log *logger.Logger) bool {
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	done, err := history.CountSince(history.KindWithdraw, outcome.Withdrawn, midnight)
+	if err != nil {
+		log.Printf("Could not read run history: %v", err)
+		return false
+	}
+	if done >= opts.DailyCap {
+		log.Printf("Daily withdraw cap of %d reached", opts.DailyCap)
+		return true
+	}
+	return false
+}
+
+func save(rec *history.Record, log *logger.Logger) {
+	if err := history.Save(rec); err != nil {
+		log.Printf("Failed to save run history: %v", err)
+	}
+}
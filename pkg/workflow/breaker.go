@@ -0,0 +1,166 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/auth"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/outcome"
+	"github.com/meetm/linkedin-automation-go/pkg/storage"
+
+	"github.com/go-rod/rod"
+)
+
+const (
+	DefaultMaxConsecutiveFailures = 3
+	DefaultMaxFailureRate         = 0.5
+
+	breakerWindow     = 10 // recent profiles the failure rate is taken over
+	breakerMinSamples = 6  // profiles needed before the rate counts
+	breakerPause      = 2 * time.Minute
+	maxBreakerTrips   = 3 // trips in one run before it gives up
+)
+
+var ErrCircuitOpen = errors.New("run stopped by circuit breaker")
+
+// breaker watches a run for a streak of failures or a high failure rate, as
+// happens when LinkedIn changes its UI or the session ends mid-run. Skipped
+// profiles neither count as failures nor end a streak. Unconfirmed sends
+// count as failures toward the rate but leave the streak as it is, since
+// they are as likely a slow page as a broken one.
+type breaker struct {
+	maxConsecutive int
+	maxRate        float64
+
+	consecutive int
+	window      []bool // recent counted outcomes, true for failures
+	trips       int
+}
+
+func newBreaker(cfg Config) *breaker {
+	b := &breaker{maxConsecutive: cfg.MaxConsecutiveFailures, maxRate: cfg.MaxFailureRate}
+	if b.maxConsecutive <= 0 {
+		b.maxConsecutive = DefaultMaxConsecutiveFailures
+	}
+	if b.maxRate <= 0 {
+		b.maxRate = DefaultMaxFailureRate
+	}
+	return b
+}
+
+// record counts an outcome and returns why the breaker trips, or "" if it does not
+func (b *breaker) record(o outcome.Outcome) string {
+	var failed bool
+	switch {
+	case o.IsFailed():
+		failed = true
+		b.consecutive++
+	case o == outcome.Unconfirmed:
+		failed = true
+	case o.IsSent(), o == outcome.Followed:
+		b.consecutive = 0
+	default:
+		return ""
+	}
+
+	b.window = append(b.window, failed)
+	if len(b.window) > breakerWindow {
+		b.window = b.window[1:]
+	}
+
+	if b.consecutive >= b.maxConsecutive {
+		return fmt.Sprintf("%d consecutive failures", b.consecutive)
+	}
+	if len(b.window) >= breakerMinSamples {
+		n := 0
+		for _, f := range b.window {
+			if f {
+				n++
+			}
+		}
+		if rate := float64(n) / float64(len(b.window)); rate > b.maxRate {
+			return fmt.Sprintf("%d of the last %d profiles failed", n, len(b.window))
+		}
+	}
+	return ""
+}
+
+// BreakerEvent is logged as a "circuit_breaker" event when the breaker
+// trips, and again when the run resumes or aborts
+type BreakerEvent struct {
+	RunID       string `json:"runId"`
+	Trip        int    `json:"trip"`
+	Reason      string `json:"reason"`
+	Action      string `json:"action"`                // "paused", "resumed" or "aborted"
+	Diagnostics string `json:"diagnostics,omitempty"` // directory holding the screenshot and page
+	Error       string `json:"error,omitempty"`
+}
+
+// trip pauses the run, saves diagnostics and checks the session, signing it
+// back in if it expired. It returns nil when the run can carry on, or the
+// error that ends it.
+func (b *breaker) trip(page *rod.Page, runID, reason string, state *runState, log *logger.Logger) error {
+	b.trips++
+	ev := BreakerEvent{RunID: runID, Trip: b.trips, Reason: reason, Action: "paused"}
+	log.Printf("Circuit breaker tripped: %s. Pausing for %s", reason, breakerPause)
+	ev.Diagnostics = saveDiagnostics(page, runID, b.trips, log)
+	log.Event("circuit_breaker", ev)
+
+	time.Sleep(breakerPause)
+
+	var err error
+	sessionErr := auth.CheckSession(page, log)
+	if errors.Is(sessionErr, outcome.ErrSessionExpired) {
+		sessionErr = reauthenticate(page, state, log)
+	}
+	state.searchPage = 0 // the session check left the search results
+	if sessionErr != nil {
+		err = fmt.Errorf("%w: %s, then the session check failed: %w", ErrCircuitOpen, reason, sessionErr)
+	} else if b.trips >= maxBreakerTrips {
+		err = fmt.Errorf("%w: %s, tripped %d times this run", ErrCircuitOpen, reason, b.trips)
+	}
+	if err != nil {
+		ev.Action, ev.Error = "aborted", err.Error()
+		log.Event("circuit_breaker", ev)
+		return err
+	}
+
+	log.Printf("Resuming after circuit breaker pause")
+	ev.Action = "resumed"
+	log.Event("circuit_breaker", ev)
+	b.consecutive, b.window = 0, nil
+	return nil
+}
+
+// saveDiagnostics writes a screenshot, the HTML and the URL of the current
+// page under diagnostics/<run>/trip-<n>, and returns that directory
+func saveDiagnostics(page *rod.Page, runID string, trip int, log *logger.Logger) string {
+	dir := filepath.Dir(storage.Path("diagnostics", runID, fmt.Sprintf("trip-%d", trip), "page.png"))
+
+	if png, err := page.Screenshot(true, nil); err == nil {
+		if err := os.WriteFile(filepath.Join(dir, "page.png"), png, 0600); err != nil {
+			log.Printf("Failed to save screenshot: %v", err)
+		}
+	} else {
+		log.Printf("Failed to take screenshot: %v", err)
+	}
+	if html, err := page.HTML(); err == nil {
+		if err := os.WriteFile(filepath.Join(dir, "page.html"), []byte(html), 0600); err != nil {
+			log.Printf("Failed to save page HTML: %v", err)
+		}
+	}
+	if info, err := page.Info(); err == nil {
+		storage.WriteJSON(filepath.Join(dir, "page.json"), map[string]interface{}{
+			"url":   info.URL,
+			"title": info.Title,
+			"at":    time.Now(),
+		})
+	}
+
+	log.Printf("Diagnostics saved to %s", dir)
+	return dir
+}
@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/meetm/linkedin-automation-go/pkg/outcome"
+)
+
+func TestBreakerRecord(t *testing.T) {
+	const (
+		ok  = outcome.Sent
+		bad = outcome.Failed
+		unc = outcome.Unconfirmed
+		skp = outcome.Skipped
+	)
+	tests := []struct {
+		name     string
+		cfg      Config
+		outcomes []outcome.Outcome
+		tripAt   int // index of the outcome that trips the breaker, -1 for none
+	}{
+		{name: "consecutive failures", outcomes: []outcome.Outcome{ok, bad, bad, bad}, tripAt: 3},
+		{name: "success ends a streak", cfg: Config{MaxFailureRate: 0.9}, outcomes: []outcome.Outcome{bad, bad, ok, bad, bad, ok}, tripAt: -1},
+		{name: "skips neither count nor end a streak", outcomes: []outcome.Outcome{bad, skp, bad, skp, skp, bad}, tripAt: 5},
+		{name: "follows count as successes", outcomes: []outcome.Outcome{bad, bad, outcome.Followed, bad, bad}, tripAt: -1},
+		{name: "unconfirmed does not end a streak", outcomes: []outcome.Outcome{bad, unc, bad, unc, bad}, tripAt: 4},
+		{name: "configured streak", cfg: Config{MaxConsecutiveFailures: 2}, outcomes: []outcome.Outcome{ok, bad, bad}, tripAt: 2},
+		{
+			name:     "failure rate over the window",
+			outcomes: []outcome.Outcome{bad, ok, bad, ok, bad, bad, ok},
+			tripAt:   5, // 4 of 6
+		},
+		{
+			name:     "rate needs enough samples",
+			cfg:      Config{MaxConsecutiveFailures: 10},
+			outcomes: []outcome.Outcome{bad, ok, bad, bad, ok},
+			tripAt:   -1,
+		},
+		{
+			name:     "rate at the limit does not trip",
+			outcomes: []outcome.Outcome{ok, bad, ok, bad, ok, bad, ok, bad},
+			tripAt:   -1,
+		},
+		{
+			name:     "unconfirmed counts toward the rate",
+			outcomes: []outcome.Outcome{unc, unc, ok, unc, unc, ok},
+			tripAt:   5, // 4 of 6; at 4 of 5 there are too few samples
+		},
+		{
+			name:     "old outcomes leave the window",
+			outcomes: []outcome.Outcome{ok, ok, ok, ok, ok, ok, ok, ok, ok, ok, bad, bad, ok, bad, bad, ok, bad, bad},
+			tripAt:   17, // 6 of the last 10, though only 6 of 18 in all
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBreaker(tt.cfg)
+			tripped := -1
+			for i, o := range tt.outcomes {
+				if reason := b.record(o); reason != "" {
+					tripped = i
+					t.Logf("tripped at %d: %s", i, reason)
+					break
+				}
+			}
+			if tripped != tt.tripAt {
+				t.Errorf("tripped at %d, want %d", tripped, tt.tripAt)
+			}
+		})
+	}
+}
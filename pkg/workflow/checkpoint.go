@@ -0,0 +1,195 @@
+package workflow
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/outcome"
+	"github.com/meetm/linkedin-automation-go/pkg/storage"
+	"github.com/meetm/linkedin-automation-go/search"
+)
+
+var (
+	ErrNoCheckpoint = errors.New("run has no checkpoint to resume from")
+	ErrRunFinished  = errors.New("run already completed")
+	ErrRunActive    = errors.New("run is already in progress")
+	ErrAborted      = errors.New("run aborted at a security checkpoint")
+
+	// ErrStopped ends a run early with profiles left. The run is recorded
+	// as stopped rather than failed and keeps its checkpoint.
+	ErrStopped = errors.New("run stopped early")
+)
+
+// how long a run waits for someone to solve a security checkpoint, or to
+// enter a two-step verification code, before it fails
+const (
+	CheckpointWait = time.Hour
+	TwoFactorWait  = 5 * time.Minute
+)
+
+// Checkpoint is the resumable state of a run, saved after every search page
+// and every processed profile
+type Checkpoint struct {
+	RunID      string                     `json:"runId"`
+	Config     Config                     `json:"config"`
+	SearchPage int                        `json:"searchPage"` // next results page to scan
+	SearchDone bool                       `json:"searchDone"`
+	Targets    []Target                   `json:"targets"`
+	Done       map[string]outcome.Outcome `json:"done"` // target key -> outcome
+	Stats      WorkflowStats              `json:"stats"`
+	UpdatedAt  time.Time                  `json:"updatedAt"`
+
+	// when LinkedIn reported the monthly note quota used up
+	NoteQuotaAt time.Time `json:"noteQuotaAt,omitzero"`
+}
+
+// runs in progress in this process
+var (
+	activeMu sync.Mutex
+	active   = map[string]bool{}
+)
+
+func newCheckpoint(cfg Config) *Checkpoint {
+	cp := &Checkpoint{RunID: cfg.RunID, Config: cfg, SearchPage: 1, Done: map[string]outcome.Outcome{}}
+
+	// credentials are never written to disk; a resumed run uses the
+	// environment and saved cookies
+	cp.Config.Email = ""
+	cp.Config.Password = ""
+	cp.Config.Targets = nil
+
+	if len(cfg.Targets) > 0 {
+		cp.Targets = limitTargets(cfg.Targets, cfg.Limit)
+		cp.SearchDone = true
+		cp.Stats.ProfilesFound = len(cp.Targets)
+	}
+	return cp
+}
+
+func checkpointPath(runID string) (string, error) {
+	if err := storage.CheckName(runID); err != nil {
+		return "", err
+	}
+	return storage.Path("checkpoints", runID+".json"), nil
+}
+
+// LoadCheckpoint reads the checkpoint of a run
+func LoadCheckpoint(runID string) (*Checkpoint, error) {
+	var cp Checkpoint
+	path, err := checkpointPath(runID)
+	if err != nil {
+		return nil, ErrNoCheckpoint
+	}
+	if err := storage.ReadJSON(path, &cp); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrNoCheckpoint
+		}
+		return nil, err
+	}
+	if cp.Done == nil {
+		cp.Done = map[string]outcome.Outcome{}
+	}
+	return &cp, nil
+}
+
+// Resumable returns the record and checkpoint of a run that can be resumed
+func Resumable(runID string) (*history.Record, *Checkpoint, error) {
+	rec, err := history.Load(runID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rec.Status == history.StatusCompleted {
+		return nil, nil, ErrRunFinished
+	}
+	if isActive(runID) {
+		return nil, nil, ErrRunActive
+	}
+	cp, err := LoadCheckpoint(runID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rec, cp, nil
+}
+
+// checkpoint persists the run record and checkpoint together
+func checkpoint(cp *Checkpoint, rec *history.Record, stats WorkflowStats, log *logger.Logger) {
+	cp.Stats = stats
+	cp.UpdatedAt = time.Now()
+	path, err := checkpointPath(cp.RunID)
+	if err == nil {
+		err = storage.WriteJSON(path, cp)
+	}
+	if err != nil {
+		log.Printf("Failed to save checkpoint: %v", err)
+	}
+	syncRecord(rec, stats)
+	saveRecord(rec, log)
+}
+
+func removeCheckpoint(runID string, log *logger.Logger) {
+	path, err := checkpointPath(runID)
+	if err == nil {
+		err = storage.Remove(path)
+	}
+	if err != nil {
+		log.Printf("Failed to remove checkpoint: %v", err)
+	}
+}
+
+func syncRecord(rec *history.Record, stats WorkflowStats) {
+	rec.ProfilesFound = stats.ProfilesFound
+	rec.SetOutcomes(stats.Outcomes)
+}
+
+// noteQuotaUsed reports whether the note quota was found used up this month
+func (cp *Checkpoint) noteQuotaUsed() bool {
+	now := time.Now()
+	return cp.NoteQuotaAt.Year() == now.Year() && cp.NoteQuotaAt.Month() == now.Month()
+}
+
+// Remaining returns the targets not yet processed
+func (cp *Checkpoint) Remaining() []Target {
+	var remaining []Target
+	for _, t := range cp.Targets {
+		if _, done := cp.Done[t.key()]; !done {
+			remaining = append(remaining, t)
+		}
+	}
+	return remaining
+}
+
+func (cp *Checkpoint) markDone(t Target, o outcome.Outcome) {
+	cp.Done[t.key()] = o
+}
+
+func (cp *Checkpoint) setCards(cards []search.Card) {
+	cp.Targets = cp.Targets[:0]
+	for _, card := range cards {
+		cp.Targets = append(cp.Targets, targetFromCard(card))
+	}
+}
+
+func begin(runID string) bool {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+	if active[runID] {
+		return false
+	}
+	active[runID] = true
+	return true
+}
+
+func end(runID string) {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+	delete(active, runID)
+}
+
+func isActive(runID string) bool {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+	return active[runID]
+}
@@ -0,0 +1,142 @@
+package workflow
+
+import (
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/actions"
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/ledger"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/outcome"
+	"github.com/meetm/linkedin-automation-go/pkg/review"
+	"github.com/meetm/linkedin-automation-go/utils"
+
+	"github.com/go-rod/rod"
+)
+
+// what to do with profiles that offer Follow but not Connect
+const (
+	FollowOnlySkip   = "skip"
+	FollowOnlyFollow = "follow"
+	FollowOnlyReview = "review" // queue for someone to approve the follow
+)
+
+// DefaultFollowCap applies when Config.FollowCap is not set
+const DefaultFollowCap = 20
+
+// followOnly applies cfg.FollowOnly to a profile without a Connect option.
+// The browser is still on the profile.
+func followOnly(page *rod.Page, target Target, cfg Config, rec *history.Record, stats *WorkflowStats, log *logger.Logger) outcome.Outcome {
+	if cfg.FollowOnly == FollowOnlyReview {
+		name, _, _ := target.profile()
+		if _, err := review.Add(review.Item{
+			Kind:       review.KindFollow,
+			CampaignID: cfg.CampaignID,
+			ProfileURL: target.ProfileURL,
+			Name:       name,
+		}); err != nil {
+			log.Printf("Failed to queue follow for review: %v", err)
+			return record(rec, stats, target, outcome.Failed, err.Error())
+		}
+		log.Printf("Follow only, queued for review")
+		return record(rec, stats, target, outcome.Queued, "follow only")
+	}
+
+	if followCapReached(cfg, log) {
+		return record(rec, stats, target, outcome.Skipped, "follow only, daily follow cap reached")
+	}
+	return follow(page, target, cfg, rec, stats, false, log)
+}
+
+// followApproved follows the campaign's follow-only profiles that have been
+// approved in the review queue since its last run
+func followApproved(page *rod.Page, cfg Config, rec *history.Record, stats *WorkflowStats, log *logger.Logger) {
+	items, err := review.List(review.KindFollow, review.StatusApproved)
+	if err != nil {
+		log.Printf("Could not read review queue: %v", err)
+		return
+	}
+
+	for _, it := range items {
+		if it.CampaignID != cfg.CampaignID {
+			continue
+		}
+		if followCapReached(cfg, log) {
+			return
+		}
+
+		target := Target{ProfileURL: it.ProfileURL}
+		if it.Name != "" {
+			target.Vars = map[string]string{"name": it.Name}
+		}
+		if follow(page, target, cfg, rec, stats, true, log) != outcome.Failed {
+			if err := review.MarkDone(it.ID); err != nil {
+				log.Printf("Failed to update review queue: %v", err)
+			}
+		}
+		utils.LongRandomSleep(5, 12)
+	}
+}
+
+// follow follows target, visiting the profile first when visit is set
+func follow(page *rod.Page, target Target, cfg Config, rec *history.Record, stats *WorkflowStats, visit bool, log *logger.Logger) outcome.Outcome {
+	var err error
+	if visit {
+		err = actions.FollowProfile(page, target.ProfileURL, log)
+	} else {
+		err = actions.Follow(page, log)
+	}
+
+	switch err {
+	case nil:
+		log.Printf("Followed %s", target.ProfileURL)
+		if err := recordFollowed(target, cfg); err != nil {
+			log.Printf("Failed to update contact ledger: %v", err)
+		}
+		return record(rec, stats, target, outcome.Followed, "follow only")
+	case actions.ErrAlreadyFollowing:
+		return record(rec, stats, target, outcome.Skipped, err.Error())
+	default:
+		log.Printf("Failed to follow: %v", err)
+		return record(rec, stats, target, outcome.Failed, err.Error())
+	}
+}
+
+// followCapReached reports whether today's follows, across campaigns, have
+// used up the cap
+func followCapReached(cfg Config, log *logger.Logger) bool {
+	limit := cfg.FollowCap
+	if limit <= 0 {
+		limit = DefaultFollowCap
+	}
+
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	followed, err := history.CountSince("", outcome.Followed, midnight)
+	if err != nil {
+		log.Printf("Could not read run history: %v", err)
+		return false
+	}
+	if followed >= limit {
+		log.Printf("Daily cap of %d follows reached", limit)
+		return true
+	}
+	return false
+}
+
+// recordFollowed adds a followed profile to the ledger so it is not visited again
+func recordFollowed(t Target, cfg Config) error {
+	id := t.identity()
+	name, headline, _ := t.profile()
+	return ledger.Put(ledger.Entry{
+		ProfileURL: id.URL(),
+		Vanity:     id.Vanity,
+		MemberID:   id.MemberID,
+		Name:       name,
+		Headline:   headline,
+		CampaignID: cfg.CampaignID,
+		RunID:      cfg.RunID,
+		Status:     ledger.StatusFollowed,
+		FollowedAt: time.Now(),
+	})
+}
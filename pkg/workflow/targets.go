@@ -0,0 +1,126 @@
+package workflow
+
+import (
+	"strings"
+
+	"github.com/meetm/linkedin-automation-go/actions"
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/ledger"
+	"github.com/meetm/linkedin-automation-go/pkg/outcome"
+	"github.com/meetm/linkedin-automation-go/pkg/profileurl"
+	"github.com/meetm/linkedin-automation-go/search"
+)
+
+// Target is a profile to contact, with optional variables for the note template
+type Target struct {
+	ProfileURL string              `json:"profileUrl"`
+	Identity   profileurl.Identity `json:"identity"`
+	Vars       map[string]string   `json:"vars,omitempty"`
+	Card       *search.Card        `json:"card,omitempty"`
+}
+
+func targetFromCard(card search.Card) Target {
+	return Target{
+		ProfileURL: card.Identity.URL(),
+		Identity:   card.Identity,
+		Vars:       card.Vars(),
+		Card:       &card,
+	}
+}
+
+// identity falls back to parsing the URL for targets supplied without one
+func (t Target) identity() profileurl.Identity {
+	if t.Identity.IsZero() {
+		id, _ := profileurl.Parse(t.ProfileURL)
+		return id
+	}
+	return t.Identity
+}
+
+func limitTargets(targets []Target, limit int) []Target {
+	if limit > 0 && len(targets) > limit {
+		return targets[:limit]
+	}
+	return targets
+}
+
+// key identifies a target across checkpoints
+func (t Target) key() string {
+	if url := t.identity().URL(); url != "" {
+		return url
+	}
+	return t.ProfileURL
+}
+
+// candidateFilter drops search results that are not worth a profile visit,
+// recording each one as skipped
+func candidateFilter(rec *history.Record, stats *WorkflowStats) search.Filter {
+	return func(card search.Card) (bool, string) {
+		reason := ""
+		switch {
+		case card.Degree == "1st":
+			reason = "already connected"
+		case card.HasPending:
+			reason = "pending request"
+		default:
+			if entry, ok, _ := ledger.Find(card.Identity); ok {
+				reason = "already contacted on " + entry.SentAt.Format("2006-01-02")
+			}
+		}
+		if reason == "" {
+			return true, ""
+		}
+		// a resumed search rescans the page it was interrupted on
+		if rec.Has(card.Identity.URL()) {
+			return false, reason
+		}
+
+		stats.ProfilesFound++
+		record(rec, stats, targetFromCard(card), outcome.Skipped, reason)
+		return false, reason
+	}
+}
+
+// profile returns the name, headline and location known for a target
+func (t Target) profile() (name, headline, location string) {
+	name = t.Vars["name"]
+	if name == "" {
+		name = strings.TrimSpace(t.Vars["firstName"] + " " + t.Vars["lastName"])
+	}
+	return name, t.Vars["headline"], t.Vars["location"]
+}
+
+// record adds the result for t to the run, counts it and returns its outcome
+func record(rec *history.Record, stats *WorkflowStats, t Target, o outcome.Outcome, reason string) outcome.Outcome {
+	stats.add(o)
+	addResult(rec, t, o, reason)
+	return o
+}
+
+func addResult(rec *history.Record, t Target, o outcome.Outcome, reason string) {
+	name, headline, location := t.profile()
+	rec.Add(history.Result{
+		ProfileURL: t.ProfileURL,
+		Name:       name,
+		Headline:   headline,
+		Location:   location,
+		Outcome:    o,
+		Reason:     reason,
+	})
+}
+
+func recordSent(t Target, result actions.ConnectionResult, cfg Config, withNote bool) error {
+	id := t.identity().Merge(result.Identity)
+	name, headline, _ := t.profile()
+	return ledger.RecordSent(ledger.Entry{
+		ProfileURL: id.URL(),
+		Vanity:     id.Vanity,
+		MemberID:   id.MemberID,
+		Name:       name,
+		Headline:   headline,
+		CampaignID: cfg.CampaignID,
+		RunID:      cfg.RunID,
+		WithNote:   withNote,
+		Template:   cfg.ConnectMessage,
+	})
+}
@@ -1,218 +1,813 @@
-package workflow
-
-import (
-	"fmt"
-	"os"
-	"path/filepath"
-	"time"
-
-	"github.com/meetm/linkedin-automation-go/actions"
-	"github.com/meetm/linkedin-automation-go/auth"
-	"github.com/meetm/linkedin-automation-go/pkg/logger"
-	"github.com/meetm/linkedin-automation-go/search"
-	"github.com/meetm/linkedin-automation-go/utils"
-
-	"github.com/go-rod/rod"
-	"github.com/go-rod/rod/lib/launcher"
-	"github.com/go-rod/rod/lib/proto"
-	"github.com/go-rod/stealth"
-)
-
-type Config struct {
-	Email          string
-	Password       string
-	Keyword        string
-	Limit          int
-	ConnectMessage string
-	Headless       bool
-}
-
-type WorkflowStats struct {
-	ProfilesFound   int
-	RequestsSent    int
-	RequestsSkipped int
-	RequestsFailed  int
-}
-
-func Run(cfg Config, log *logger.Logger) {
-	log.Printf("Starting LinkedIn automation...")
-
-	browser, page, err := initBrowser(cfg.Headless, log)
-	if err != nil {
-		log.Printf("Browser initialization failed: %v", err)
-		return
-	}
-	defer browser.MustClose()
-
-	if cfg.Email != "" {
-		os.Setenv("LINKEDIN_EMAIL", cfg.Email)
-	}
-	if cfg.Password != "" {
-		os.Setenv("LINKEDIN_PASSWORD", cfg.Password)
-	}
-
-	log.Printf("Performing login...")
-	if err := auth.Login(page, log); err != nil {
-		log.Printf("Login failed: %v", err)
-		return
-	}
-
-	utils.LongRandomSleep(2, 4)
-
-	profiles := search.Run(page, cfg.Keyword, cfg.Limit, log)
-	if len(profiles) == 0 {
-		log.Printf("No profiles found. Exiting.")
-		return
-	}
-
-	log.Printf("Found %d profiles. Starting connection requests...", len(profiles))
-
-	stats := processProfiles(page, profiles, cfg.ConnectMessage, log)
-
-	log.Printf("Workflow complete! Sent: %d, Skipped: %d, Failed: %d",
-		stats.RequestsSent, stats.RequestsSkipped, stats.RequestsFailed)
-}
-
-func initBrowser(headless bool, log *logger.Logger) (*rod.Browser, *rod.Page, error) {
-	userDataDir := getUserDataDir()
-	log.Printf("Using browser profile: %s", userDataDir)
-
-	cleanupProfileLocks(userDataDir, log)
-
-	log.Printf("Launching browser...")
-
-	var u string
-	var err error
-	for i := 0; i < 3; i++ {
-		l := launcher.New().
-			Headless(headless).
-			Leakless(false).
-			UserDataDir(userDataDir).
-			Set("disable-blink-features", "AutomationControlled").
-			Set("disable-dev-shm-usage").
-			Set("no-first-run").
-			Set("no-default-browser-check").
-			Set("disable-infobars").
-			Set("disable-extensions").
-			Set("disable-popup-blocking").
-			Set("ignore-certificate-errors").
-			Set("disable-background-networking").
-			Set("disable-sync").
-			Set("disable-translate").
-			Set("metrics-recording-only").
-			Set("safebrowsing-disable-auto-update").
-			Set("password-store", "basic")
-
-		u, err = l.Launch()
-		if err == nil {
-			break
-		}
-		log.Printf("Browser launch attempt %d failed: %v", i+1, err)
-		if i < 2 {
-			log.Printf("Cleaning up and retrying...")
-			cleanupProfileLocks(userDataDir, log)
-			time.Sleep(3 * time.Second)
-		}
-	}
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to launch browser after 3 attempts: %w", err)
-	}
-
-	browser := rod.New().ControlURL(u).MustConnect()
-	time.Sleep(time.Second * 2)
-
-	page := stealth.MustPage(browser)
-
-	page.MustSetViewport(1920, 1080, 1, false)
-
-	page.SetUserAgent(&proto.NetworkSetUserAgentOverride{
-		UserAgent:      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36",
-		AcceptLanguage: "en-US,en;q=0.9",
-		Platform:       "Win32",
-	})
-
-	applyStealthScripts(page)
-
-	log.Printf("Browser ready")
-	return browser, page, nil
-}
-
-func applyStealthScripts(page *rod.Page) {
-	page.MustEval(`() => {
-		Object.defineProperty(navigator, 'webdriver', {get: () => undefined});
-		
-		Object.defineProperty(navigator, 'languages', {get: () => ['en-US', 'en']});
-		
-		Object.defineProperty(navigator, 'plugins', {
-			get: () => [1, 2, 3, 4, 5]
-		});
-		
-		const originalQuery = window.navigator.permissions.query;
-		window.navigator.permissions.query = (parameters) => (
-			parameters.name === 'notifications' ?
-				Promise.resolve({ state: Notification.permission }) :
-				originalQuery(parameters)
-		);
-		
-		Object.defineProperty(navigator, 'platform', {get: () => 'Win32'});
-		
-		Object.defineProperty(navigator, 'hardwareConcurrency', {get: () => 8});
-		
-		Object.defineProperty(navigator, 'deviceMemory', {get: () => 8});
-		
-		const getParameter = WebGLRenderingContext.prototype.getParameter;
-		WebGLRenderingContext.prototype.getParameter = function(parameter) {
-			if (parameter === 37445) return 'Intel Inc.';
-			if (parameter === 37446) return 'Intel Iris OpenGL Engine';
-			return getParameter.apply(this, arguments);
-		};
-		
-		window.chrome = {runtime: {}};
-	}`)
-}
-
-func getUserDataDir() string {
-	home, _ := os.UserHomeDir()
-	return home + "/.linkedin-automation-profile"
-}
-
-func cleanupProfileLocks(profileDir string, log *logger.Logger) {
-	lockFiles := []string{
-		"SingletonLock",
-		"SingletonSocket",
-		"SingletonCookie",
-	}
-
-	for _, lockFile := range lockFiles {
-		lockPath := filepath.Join(profileDir, lockFile)
-		if _, err := os.Stat(lockPath); err == nil {
-			log.Printf("Removing stale lock file: %s", lockFile)
-			os.Remove(lockPath)
-		}
-	}
-}
-
-func processProfiles(page *rod.Page, profiles []string, message string, log *logger.Logger) WorkflowStats {
-	stats := WorkflowStats{ProfilesFound: len(profiles)}
-
-	for i, profile := range profiles {
-		log.Printf("Processing %d/%d...", i+1, len(profiles))
-
-		result := actions.SendConnectionRequest(page, profile, message, log)
-
-		if result.Success {
-			stats.RequestsSent++
-		} else if result.Skipped {
-			stats.RequestsSkipped++
-		} else {
-			stats.RequestsFailed++
-		}
-
-		if i < len(profiles)-1 {
-			log.Printf("Cooling down...")
-			utils.LongRandomSleep(5, 12)
-		}
-	}
-
-	return stats
-}
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/actions"
+	"github.com/meetm/linkedin-automation-go/auth"
+	"github.com/meetm/linkedin-automation-go/pkg/handoff"
+	"github.com/meetm/linkedin-automation-go/pkg/history"
+	"github.com/meetm/linkedin-automation-go/pkg/ledger"
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/note"
+	"github.com/meetm/linkedin-automation-go/pkg/outcome"
+	"github.com/meetm/linkedin-automation-go/pkg/retry"
+	"github.com/meetm/linkedin-automation-go/pkg/review"
+	"github.com/meetm/linkedin-automation-go/search"
+	"github.com/meetm/linkedin-automation-go/utils"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/stealth"
+)
+
+type Config struct {
+	RunID          string
+	CampaignID     string
+	Email          string
+	Password       string
+	Keyword        string
+	Search         search.Criteria
+	Limit          int
+	ConnectMessage string
+	Headless       bool
+	DryRun         bool
+	DailyCap       int
+	Targets        []Target
+
+	// ConnectFromCards sends invitations from search result cards when
+	// they offer Connect, visiting the profile only otherwise
+	ConnectFromCards bool
+
+	// FollowOnly is the policy for profiles offering Follow but not Connect:
+	// FollowOnlySkip (the default), FollowOnlyFollow or FollowOnlyReview.
+	// FollowCap caps follows per day across campaigns.
+	FollowOnly string
+	FollowCap  int
+
+	// NotePolicy applies when ConnectMessage is set but the note cannot be
+	// added; empty means actions.NoteFallback
+	NotePolicy actions.NotePolicy
+
+	// MaxConsecutiveFailures and MaxFailureRate (0-1, over the last 10
+	// profiles) trip the circuit breaker; zero uses the defaults
+	MaxConsecutiveFailures int
+	MaxFailureRate         float64
+}
+
+// WorkflowStats counts the profiles a run found and the outcome of each
+type WorkflowStats struct {
+	ProfilesFound int
+	Outcomes      outcome.Counts
+}
+
+func (s *WorkflowStats) add(o outcome.Outcome) {
+	if s.Outcomes == nil {
+		s.Outcomes = outcome.Counts{}
+	}
+	s.Outcomes[o]++
+}
+
+// Run executes a full search-and-connect workflow and records it in the run history
+func Run(cfg Config, log *logger.Logger) (WorkflowStats, error) {
+	if cfg.RunID == "" {
+		cfg.RunID = history.NewID()
+	}
+
+	criteria := cfg.Criteria()
+
+	rec := &history.Record{
+		ID:         cfg.RunID,
+		CampaignID: cfg.CampaignID,
+		Keyword:    criteria.Keywords,
+		Criteria:   criteria,
+		Limit:      cfg.Limit,
+		DryRun:     cfg.DryRun,
+		Status:     history.StatusRunning,
+		StartedAt:  time.Now(),
+	}
+
+	if len(cfg.Targets) > 0 {
+		rec.Source = history.SourceImport
+	} else {
+		rec.Source = history.SourceSearch
+	}
+
+	cp := newCheckpoint(cfg)
+	if len(cfg.Targets) == 0 {
+		if err := criteria.Validate(); err != nil {
+			return finish(rec, cp, cp.Stats, err, log)
+		}
+	}
+	return execute(cfg, rec, cp, log)
+}
+
+// Resume continues an interrupted or failed run from its last checkpoint
+func Resume(runID string, log *logger.Logger) (WorkflowStats, error) {
+	rec, cp, err := Resumable(runID)
+	if err != nil {
+		return WorkflowStats{}, err
+	}
+
+	log.Printf("Resuming run %s", runID)
+	rec.Status = history.StatusRunning
+	rec.Error = ""
+	rec.Stack = ""
+	rec.StopReason = ""
+	rec.FinishedAt = time.Time{}
+	return execute(cp.Config, rec, cp, log)
+}
+
+// execute runs the workflow from cp, guarding against running the same run twice
+func execute(cfg Config, rec *history.Record, cp *Checkpoint, log *logger.Logger) (WorkflowStats, error) {
+	if !begin(rec.ID) {
+		return cp.Stats, ErrRunActive
+	}
+	defer end(rec.ID)
+
+	saveRecord(rec, log)
+	stats, err := run(cfg, rec, cp, log)
+	return finish(rec, cp, stats, err, log)
+}
+
+// finish records the outcome of a run. Completed runs drop their checkpoint;
+// stopped and failed ones keep it so they can be resumed. Stopping early is
+// not an error.
+func finish(rec *history.Record, cp *Checkpoint, stats WorkflowStats, err error, log *logger.Logger) (WorkflowStats, error) {
+	rec.FinishedAt = time.Now()
+	if errors.Is(err, ErrStopped) {
+		rec.Status = history.StatusStopped
+		rec.StopReason = err.Error()
+		log.Printf("Run stopped with %d profiles left (%v). Continue it later with resume %s", len(cp.Remaining()), err, rec.ID)
+		checkpoint(cp, rec, stats, log)
+		return stats, nil
+	}
+	if err != nil {
+		rec.Fail(err)
+		checkpoint(cp, rec, stats, log)
+	} else {
+		rec.Status = history.StatusCompleted
+		syncRecord(rec, stats)
+		saveRecord(rec, log)
+		removeCheckpoint(rec.ID, log)
+	}
+	return stats, err
+}
+
+// run drives the browser through the workflow. A panic fails the run with
+// the stats counted so far; the browser is closed on the way out.
+func run(cfg Config, rec *history.Record, cp *Checkpoint, log *logger.Logger) (stats WorkflowStats, err error) {
+	defer outcome.Recover(&err, log)
+	stats = cp.Stats
+
+	log.Printf("Starting LinkedIn automation...")
+
+	// imported profiles need no browser to preview
+	if cfg.DryRun && rec.Source == history.SourceImport {
+		dryRun(cp, cfg, rec, &stats, log)
+		return stats, nil
+	}
+
+	browser, page, err := Session(cfg, log)
+	if err != nil {
+		return stats, err
+	}
+	defer CloseBrowser(browser, log)
+
+	state := &runState{runID: rec.ID}
+	if rec.Source == history.SourceImport {
+		log.Printf("Using %d imported profiles, skipping search", len(cp.Targets))
+	} else if !cp.SearchDone {
+		if err := searchTargets(page, cfg, rec, cp, &stats, state, log); err != nil {
+			return stats, err
+		}
+	}
+
+	if cfg.CampaignID != "" && !cfg.DryRun {
+		followApproved(page, cfg, rec, &stats, log)
+	}
+
+	if len(cp.Targets) == 0 {
+		log.Printf("No profiles found. Exiting.")
+		return stats, nil
+	}
+
+	if cfg.DryRun {
+		dryRun(cp, cfg, rec, &stats, log)
+		return stats, nil
+	}
+
+	log.Printf("Found %d profiles. Starting connection requests...", len(cp.Targets))
+
+	if err := processProfiles(page, cp, cfg, rec, &stats, state, log); err != nil {
+		return stats, err
+	}
+
+	log.Printf("Workflow complete! Sent: %d, Followed: %d, Skipped: %d, Failed: %d",
+		stats.Outcomes.Sent(), stats.Outcomes[outcome.Followed], stats.Outcomes.Skipped(), stats.Outcomes.Failed())
+	return stats, nil
+}
+
+// searchTargets collects targets from people search, checkpointing after
+// every results page so a resumed run continues on the next one. An error
+// opening the results, or an expired session that could not be signed back
+// in, fails the run with the search left to resume.
+func searchTargets(page *rod.Page, cfg Config, rec *history.Record, cp *Checkpoint, stats *WorkflowStats, state *runState, log *logger.Logger) error {
+	var found []search.Card
+	for _, t := range cp.Targets {
+		if t.Card != nil {
+			found = append(found, *t.Card)
+		}
+	}
+	if cp.SearchPage > 1 {
+		log.Printf("Resuming search at page %d with %d profiles found", cp.SearchPage, len(found))
+	}
+
+	opts := search.Options{
+		Limit:     cfg.Limit,
+		Filter:    candidateFilter(rec, stats),
+		StartPage: cp.SearchPage,
+		Found:     found,
+		OnPage: func(next int, found []search.Card) {
+			cp.SearchPage = next
+			cp.setCards(found)
+			checkpoint(cp, rec, *stats, log)
+		},
+	}
+	cards, err := search.Run(page, cfg.Criteria(), opts, log)
+	for errors.Is(err, outcome.ErrSessionExpired) {
+		if err := reauthenticate(page, state, log); err != nil {
+			return err
+		}
+		cards, err = search.Run(page, cfg.Criteria(), opts, log)
+	}
+	if err != nil {
+		return fmt.Errorf("search: %w", err)
+	}
+
+	cp.setCards(cards)
+	cp.SearchDone = true
+	stats.ProfilesFound += len(cards)
+	checkpoint(cp, rec, *stats, log)
+	return nil
+}
+
+// dryRun records what would be sent to each target without touching LinkedIn
+func dryRun(cp *Checkpoint, cfg Config, rec *history.Record, stats *WorkflowStats, log *logger.Logger) {
+	for _, t := range cp.Remaining() {
+		message, err := note.Render(cfg.ConnectMessage, t.Vars)
+		switch {
+		case err != nil:
+			log.Printf("Dry run: would skip %s: %v", t.ProfileURL, err)
+			record(rec, stats, t, outcome.DryRun, err.Error())
+		case message != "":
+			log.Printf("Dry run: would connect to %s with note %q", t.ProfileURL, message)
+			record(rec, stats, t, outcome.DryRun, "dry run")
+		default:
+			log.Printf("Dry run: would connect to %s", t.ProfileURL)
+			record(rec, stats, t, outcome.DryRun, "dry run")
+		}
+		cp.markDone(t, outcome.DryRun)
+	}
+	log.Printf("Dry run complete! Found %d profiles, no requests sent", len(cp.Targets))
+}
+
+// Session opens the browser and signs in. The caller closes the browser.
+func Session(cfg Config, log *logger.Logger) (*rod.Browser, *rod.Page, error) {
+	cfg.RunID = handoffID(cfg.RunID)
+	browser, page, err := initBrowser(cfg.Headless, log)
+	if err != nil {
+		log.Printf("Browser initialization failed: %v", err)
+		return nil, nil, err
+	}
+	defer func() {
+		// the caller cannot close a browser it never got
+		if v := recover(); v != nil {
+			CloseBrowser(browser, log)
+			panic(v)
+		}
+	}()
+
+	setCredentials(cfg)
+
+	log.Printf("Performing login...")
+	err = auth.Login(page, codePrompt(cfg.RunID, log), log)
+	if errors.Is(err, auth.ErrCaptchaDetected) {
+		browser, page, err = handOff(browser, page, cfg, log)
+	}
+	if err != nil {
+		log.Printf("Login failed: %v", err)
+		if browser != nil {
+			CloseBrowser(browser, log)
+		}
+		return nil, nil, err
+	}
+
+	utils.LongRandomSleep(2, 4)
+	return browser, page, nil
+}
+
+// Login opens the browser, signs in and persists the session cookies
+func Login(cfg Config, log *logger.Logger) error {
+	cfg.RunID = handoffID(cfg.RunID)
+	browser, page, err := initBrowser(cfg.Headless, log)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// a checkpoint handoff may have replaced the browser
+		if browser != nil {
+			CloseBrowser(browser, log)
+		}
+	}()
+
+	setCredentials(cfg)
+
+	err = auth.Login(page, codePrompt(cfg.RunID, log), log)
+	if errors.Is(err, auth.ErrCaptchaDetected) {
+		browser, _, err = handOff(browser, page, cfg, log)
+	}
+	if err != nil {
+		return err
+	}
+	return auth.SaveCookies(browser, auth.CookieFile, log)
+}
+
+// handOff pauses at a security checkpoint until someone solves it in the
+// browser and continues the run, or aborts it. A headless browser is first
+// replaced by one with a window. The run is marked needs-human meanwhile.
+// On error the browser it returns, if any, is still open.
+func handOff(browser *rod.Browser, page *rod.Page, cfg Config, log *logger.Logger) (*rod.Browser, *rod.Page, error) {
+	url := auth.LoginURL
+	if info, err := page.Info(); err == nil {
+		url = info.URL
+	}
+
+	if cfg.Headless {
+		log.Printf("Reopening the browser with a window so the checkpoint can be solved...")
+		CloseBrowser(browser, log)
+		var err error
+		if browser, page, err = initBrowser(false, log); err != nil {
+			return nil, nil, fmt.Errorf("%w, and no browser window could be opened: %v", auth.ErrCaptchaDetected, err)
+		}
+		// signing in again brings the checkpoint back up in the new window
+		if err := auth.Login(page, codePrompt(cfg.RunID, log), log); !errors.Is(err, auth.ErrCaptchaDetected) {
+			return browser, page, err
+		}
+	}
+
+	setStatus(cfg.RunID, history.StatusNeedsHuman, log)
+	answer, err := handoff.Wait(handoff.Request{
+		RunID:  cfg.RunID,
+		Kind:   handoff.KindCheckpoint,
+		Reason: "LinkedIn security checkpoint",
+		URL:    url,
+		Headed: true,
+	}, CheckpointWait, log)
+	setStatus(cfg.RunID, history.StatusRunning, log)
+
+	switch {
+	case err != nil:
+		return browser, page, fmt.Errorf("%w: %v", auth.ErrCaptchaDetected, err)
+	case answer.Decision == handoff.Abort:
+		return browser, page, ErrAborted
+	}
+	if err := auth.CheckSession(page, log); err != nil {
+		return browser, page, fmt.Errorf("checkpoint not solved: %w", err)
+	}
+	return browser, page, auth.SaveCookies(browser, auth.CookieFile, log)
+}
+
+// codePrompt asks for a two-step verification code through the handoff
+// API, or the terminal outside the server
+func codePrompt(runID string, log *logger.Logger) auth.CodePrompt {
+	return func() (string, error) {
+		setStatus(runID, history.StatusNeedsHuman, log)
+		defer setStatus(runID, history.StatusRunning, log)
+
+		answer, err := handoff.Wait(handoff.Request{
+			RunID:  runID,
+			Kind:   handoff.KindTwoFactor,
+			Reason: "LinkedIn two-step verification code",
+		}, TwoFactorWait, log)
+		if err != nil {
+			return "", err
+		}
+		if answer.Decision == handoff.Abort {
+			return "", ErrAborted
+		}
+		return answer.Code, nil
+	}
+}
+
+// handoffID is the ID a job's handoffs are answered by: its run ID, or a
+// fresh one for jobs outside the run history
+func handoffID(runID string) string {
+	if runID == "" {
+		return history.NewID()
+	}
+	return runID
+}
+
+// setStatus updates the saved record of a run, if it has one
+func setStatus(runID string, status history.Status, log *logger.Logger) {
+	if runID == "" {
+		return
+	}
+	if err := history.SetStatus(runID, status); err != nil && !errors.Is(err, history.ErrRunNotFound) {
+		log.Printf("Failed to update run status: %v", err)
+	}
+}
+
+// Criteria returns the search criteria, with Keyword filling in for empty Search keywords
+func (cfg Config) Criteria() search.Criteria {
+	c := cfg.Search
+	if c.Keywords == "" {
+		c.Keywords = cfg.Keyword
+	}
+	return c
+}
+
+func setCredentials(cfg Config) {
+	if cfg.Email != "" {
+		os.Setenv("LINKEDIN_EMAIL", cfg.Email)
+	}
+	if cfg.Password != "" {
+		os.Setenv("LINKEDIN_PASSWORD", cfg.Password)
+	}
+}
+
+func saveRecord(rec *history.Record, log *logger.Logger) {
+	if err := history.Save(rec); err != nil {
+		log.Printf("Failed to save run history: %v", err)
+	}
+}
+
+func initBrowser(headless bool, log *logger.Logger) (*rod.Browser, *rod.Page, error) {
+	userDataDir := getUserDataDir()
+	log.Printf("Using browser profile: %s", userDataDir)
+
+	cleanupProfileLocks(userDataDir, log)
+
+	log.Printf("Launching browser...")
+
+	var u string
+	err := retry.Do(context.Background(), retry.Launch, log, "Browser launch", func() error {
+		l := launcher.New().
+			Headless(headless).
+			Leakless(false).
+			UserDataDir(userDataDir).
+			Set("disable-blink-features", "AutomationControlled").
+			Set("disable-dev-shm-usage").
+			Set("no-first-run").
+			Set("no-default-browser-check").
+			Set("disable-infobars").
+			Set("disable-extensions").
+			Set("disable-popup-blocking").
+			Set("ignore-certificate-errors").
+			Set("disable-background-networking").
+			Set("disable-sync").
+			Set("disable-translate").
+			Set("metrics-recording-only").
+			Set("safebrowsing-disable-auto-update").
+			Set("password-store", "basic")
+
+		var err error
+		if u, err = l.Launch(); err != nil {
+			// a crashed launch can leave the profile locked
+			cleanupProfileLocks(userDataDir, log)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to launch browser: %w", err)
+	}
+
+	browser := rod.New().ControlURL(u)
+	if err := browser.Connect(); err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to browser: %w", err)
+	}
+	time.Sleep(time.Second * 2)
+
+	page, err := preparePage(browser)
+	if err != nil {
+		CloseBrowser(browser, log)
+		return nil, nil, fmt.Errorf("failed to open page: %w", err)
+	}
+
+	log.Printf("Browser ready")
+	return browser, page, nil
+}
+
+// preparePage opens the stealth page every job drives
+func preparePage(browser *rod.Browser) (*rod.Page, error) {
+	page, err := stealth.Page(browser)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := page.SetViewport(&proto.EmulationSetDeviceMetricsOverride{
+		Width:             1920,
+		Height:            1080,
+		DeviceScaleFactor: 1,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := page.SetUserAgent(&proto.NetworkSetUserAgentOverride{
+		UserAgent:      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36",
+		AcceptLanguage: "en-US,en;q=0.9",
+		Platform:       "Win32",
+	}); err != nil {
+		return nil, err
+	}
+
+	return page, applyStealthScripts(page)
+}
+
+// CloseBrowser closes a browser opened by Session, logging any failure
+func CloseBrowser(browser *rod.Browser, log *logger.Logger) {
+	if err := browser.Close(); err != nil {
+		log.Printf("Failed to close browser: %v", err)
+	}
+}
+
+func applyStealthScripts(page *rod.Page) error {
+	_, err := page.Eval(`() => {
+		Object.defineProperty(navigator, 'webdriver', {get: () => undefined});
+		
+		Object.defineProperty(navigator, 'languages', {get: () => ['en-US', 'en']});
+		
+		Object.defineProperty(navigator, 'plugins', {
+			get: () => [1, 2, 3, 4, 5]
+		});
+		
+		const originalQuery = window.navigator.permissions.query;
+		window.navigator.permissions.query = (parameters) => (
+			parameters.name === 'notifications' ?
+				Promise.resolve({ state: Notification.permission }) :
+				originalQuery(parameters)
+		);
+		
+		Object.defineProperty(navigator, 'platform', {get: () => 'Win32'});
+		
+		Object.defineProperty(navigator, 'hardwareConcurrency', {get: () => 8});
+		
+		Object.defineProperty(navigator, 'deviceMemory', {get: () => 8});
+		
+		const getParameter = WebGLRenderingContext.prototype.getParameter;
+		WebGLRenderingContext.prototype.getParameter = function(parameter) {
+			if (parameter === 37445) return 'Intel Inc.';
+			if (parameter === 37446) return 'Intel Iris OpenGL Engine';
+			return getParameter.apply(this, arguments);
+		};
+		
+		window.chrome = {runtime: {}};
+	}`)
+	return err
+}
+
+func getUserDataDir() string {
+	home, _ := os.UserHomeDir()
+	return home + "/.linkedin-automation-profile"
+}
+
+func cleanupProfileLocks(profileDir string, log *logger.Logger) {
+	lockFiles := []string{
+		"SingletonLock",
+		"SingletonSocket",
+		"SingletonCookie",
+	}
+
+	for _, lockFile := range lockFiles {
+		lockPath := filepath.Join(profileDir, lockFile)
+		if _, err := os.Stat(lockPath); err == nil {
+			log.Printf("Removing stale lock file: %s", lockFile)
+			os.Remove(lockPath)
+		}
+	}
+}
+
+// processProfiles works through the remaining targets. It returns an error
+// when the circuit breaker ends the run or the session cannot be restored,
+// and ErrStopped when the daily cap, a rate limit or the note quota stops it
+// with targets left.
+func processProfiles(page *rod.Page, cp *Checkpoint, cfg Config, rec *history.Record, stats *WorkflowStats, state *runState, log *logger.Logger) error {
+	targets := cp.Remaining()
+	if done := len(cp.Targets) - len(targets); done > 0 {
+		log.Printf("Skipping %d profiles already processed before resuming", done)
+	}
+	if cp.noteQuotaUsed() {
+		state.noteQuota = true
+	}
+	breaker := newBreaker(cfg)
+
+	var stop string
+	for i, target := range targets {
+		log.Printf("Processing %d/%d...", i+1, len(targets))
+
+		if capReached(cfg, log) {
+			stop = fmt.Sprintf("daily cap of %d reached", cfg.DailyCap)
+			break
+		}
+		if state.noteQuota && cfg.NotePolicy != "" && cfg.NotePolicy != actions.NoteFallback {
+			log.Printf("Monthly note quota exhausted and notePolicy is %s, stopping", cfg.NotePolicy)
+			stop = "monthly note quota exhausted"
+			break
+		}
+
+		o := processTarget(page, target, cfg, rec, stats, state, log)
+		for state.sessionExpired {
+			// the target was not recorded, so it is tried again once signed in
+			if err := reauthenticate(page, state, log); err != nil {
+				return err
+			}
+			o = processTarget(page, target, cfg, rec, stats, state, log)
+		}
+		cp.markDone(target, o)
+		if state.noteQuota && !cp.noteQuotaUsed() {
+			cp.NoteQuotaAt = time.Now()
+		}
+		checkpoint(cp, rec, *stats, log)
+
+		if state.rateLimited {
+			log.Printf("Rate limited by LinkedIn, stopping")
+			stop = "rate limited by LinkedIn"
+			break
+		}
+
+		if i == len(targets)-1 {
+			break
+		}
+		if reason := breaker.record(o); reason != "" {
+			if err := breaker.trip(page, rec.ID, reason, state, log); err != nil {
+				return err
+			}
+			continue
+		}
+
+		log.Printf("Cooling down...")
+		utils.LongRandomSleep(5, 12)
+	}
+	if stop != "" && len(cp.Remaining()) > 0 {
+		return fmt.Errorf("%w: %s", ErrStopped, stop)
+	}
+	return nil
+}
+
+// runState is what processing one target learns for the next ones
+type runState struct {
+	runID           string
+	searchPage      int  // results page the browser is on, 0 when elsewhere
+	noteQuota       bool // no notes can be added until next month
+	rateLimited     bool // LinkedIn refuses further invitations for now
+	sessionExpired  bool // LinkedIn signed us out while processing the target
+	reauthenticated bool // the session was already restored once this run
+}
+
+// reauthenticate signs the session back in after it expired mid-run. It is
+// tried once per run; a session that expires again stops the run.
+func reauthenticate(page *rod.Page, state *runState, log *logger.Logger) error {
+	state.sessionExpired = false
+	state.searchPage = 0
+	if state.reauthenticated {
+		return fmt.Errorf("%w again after signing back in", outcome.ErrSessionExpired)
+	}
+	state.reauthenticated = true
+
+	log.Printf("Session expired, signing back in...")
+	if err := auth.Reauthenticate(page, codePrompt(state.runID, log), log); err != nil {
+		return fmt.Errorf("%w and signing back in failed: %v", outcome.ErrSessionExpired, err)
+	}
+	log.Printf("Signed back in, continuing")
+	return nil
+}
+
+// processTarget sends one invitation and returns the outcome recorded for
+// it, or records nothing and sets state.sessionExpired if LinkedIn signed
+// us out
+func processTarget(page *rod.Page, target Target, cfg Config, rec *history.Record, stats *WorkflowStats, state *runState, log *logger.Logger) outcome.Outcome {
+	if entry, ok, _ := ledger.Find(target.identity()); ok {
+		log.Printf("Skipping: already contacted on %s", entry.SentAt.Format("2006-01-02"))
+		return record(rec, stats, target, outcome.Skipped, "already in ledger")
+	}
+	if _, queued, _ := review.Find(review.KindFollow, target.ProfileURL, 0); queued {
+		log.Printf("Skipping: follow already queued for review")
+		return record(rec, stats, target, outcome.Skipped, "queued for review")
+	}
+
+	message, err := note.Render(cfg.ConnectMessage, target.Vars)
+	if err != nil {
+		log.Printf("Skipping: %v", err)
+		return record(rec, stats, target, outcome.Skipped, err.Error())
+	}
+	wantNote := message != ""
+	if wantNote && state.noteQuota {
+		// only NoteFallback gets here, processProfiles stops otherwise
+		message = ""
+	}
+
+	var result actions.ConnectionResult
+	if cfg.ConnectFromCards && target.Card != nil && target.Card.HasConnect {
+		result, state.searchPage = connectFromCard(page, target, message, cfg, state.searchPage, log)
+	}
+	if result.Outcome == "" || errors.Is(result.Error, actions.ErrNoCardConnect) {
+		if result.Error != nil {
+			log.Printf("Card has no Connect button, visiting profile instead")
+		}
+		result = actions.SendConnectionRequest(page, target.ProfileURL, message, cfg.NotePolicy, log)
+		state.searchPage = 0
+	}
+	if errors.Is(result.Error, outcome.ErrSessionExpired) {
+		state.sessionExpired = true
+		return ""
+	}
+	state.rateLimited = errors.Is(result.Error, outcome.ErrRateLimited)
+	if result.NoteQuota && !state.noteQuota {
+		log.Printf("Monthly note quota exhausted, no more notes this run")
+		state.noteQuota = true
+	}
+
+	o := result.Outcome
+	if o == outcome.Sent && wantNote && message == "" {
+		o = outcome.SentWithoutNote
+	}
+
+	switch o {
+	case outcome.Sent, outcome.SentWithoutNote, outcome.Unconfirmed:
+		// an unconfirmed invitation may well have gone out, so the ledger
+		// keeps us from sending it twice; reconcile settles what happened
+		if err := recordSent(target, result, cfg, message != "" && o != outcome.SentWithoutNote); err != nil {
+			log.Printf("Failed to update contact ledger: %v", err)
+		}
+		reason := result.Reason
+		if o == outcome.SentWithoutNote {
+			reason = actions.ErrNoteUnavailable.Error()
+			if state.noteQuota {
+				reason = actions.ErrNoteQuota.Error()
+			}
+		}
+		return record(rec, stats, target, o, reason)
+	case outcome.NoteUnavailable, outcome.NoteRequired:
+		// not sent, so a later run tries again
+		return record(rec, stats, target, o, result.Error.Error())
+	case outcome.Skipped:
+		if errors.Is(result.Error, actions.ErrFollowOnly) && cfg.FollowOnly != "" && cfg.FollowOnly != FollowOnlySkip {
+			return followOnly(page, target, cfg, rec, stats, log)
+		}
+		return record(rec, stats, target, o, result.Reason)
+	default:
+		return record(rec, stats, target, outcome.Failed, errorReason(result))
+	}
+}
+
+// connectFromCard returns to the card's results page if needed and sends the
+// invitation from the card, returning the results page the browser is now on
+func connectFromCard(page *rod.Page, target Target, message string, cfg Config, searchPage int, log *logger.Logger) (actions.ConnectionResult, int) {
+	if searchPage != target.Card.Page {
+		if err := search.OpenPage(page, cfg.Criteria(), target.Card.Page, log); err != nil {
+			log.Printf("Could not return to search page %d: %v", target.Card.Page, err)
+			return actions.ConnectionResult{Error: actions.ErrNoCardConnect}, 0
+		}
+		searchPage = target.Card.Page
+	}
+	return actions.SendConnectionFromCard(page, target.identity(), message, cfg.NotePolicy, log), searchPage
+}
+
+// capReached reports whether the campaign's daily invitation cap has been used up
+func capReached(cfg Config, log *logger.Logger) bool {
+	if cfg.DailyCap <= 0 {
+		return false
+	}
+
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	sent, err := ledger.CountSentSince(cfg.CampaignID, midnight)
+	if err != nil {
+		log.Printf("Could not read contact ledger: %v", err)
+		return false
+	}
+	if sent >= cfg.DailyCap {
+		log.Printf("Daily cap of %d requests reached, stopping", cfg.DailyCap)
+		return true
+	}
+	return false
+}
+
+// errorReason names the step of the connection flow that failed
+func errorReason(result actions.ConnectionResult) string {
+	reason := result.Reason
+	if result.Error != nil {
+		reason = result.Error.Error()
+	}
+	if result.Step != "" {
+		return fmt.Sprintf("%s (%s): %s", result.Step, result.Code, reason)
+	}
+	return reason
+}
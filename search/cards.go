@@ -0,0 +1,183 @@
+package search
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/meetm/linkedin-automation-go/pkg/profileurl"
+	"github.com/meetm/linkedin-automation-go/utils"
+
+	"github.com/go-rod/rod"
+)
+
+// Card is a single people-search result, read without visiting the profile
+type Card struct {
+	Identity   profileurl.Identity `json:"identity"`
+	Name       string              `json:"name"`
+	Headline   string              `json:"headline,omitempty"`
+	Location   string              `json:"location,omitempty"`
+	Degree     string              `json:"degree,omitempty"` // "1st", "2nd", "3rd+" or empty when out of network
+	HasConnect bool                `json:"hasConnect"`
+	HasPending bool                `json:"hasPending,omitempty"`
+	HasFollow  bool                `json:"hasFollow,omitempty"`
+	Page       int                 `json:"page,omitempty"` // search results page the card was found on
+}
+
+// Filter decides which cards are worth visiting; reason is logged for rejected cards
+type Filter func(Card) (keep bool, reason string)
+
+// result card containers, newest layout first. Only the first selector that
+// matches anything is used, so sidebars and "people also viewed" modules
+// elsewhere on the page are never read.
+var cardSelectors = []string{
+	"div[data-view-name='search-entity-result-universal-template']",
+	"li.reusable-search__result-container",
+	"div[data-chameleon-result-urn]",
+	"div.entity-result",
+}
+
+var degreePattern = regexp.MustCompile(`\b(1st|2nd|3rd\+?)\b`)
+
+// reads the fields of one card in a single round trip
+const readCardJS = `function() {
+	const text = (sel) => {
+		const el = this.querySelector(sel);
+		return el ? el.innerText.trim() : '';
+	};
+	const link = this.querySelector(".entity-result__title-text a[href*='/in/'], a[href*='/in/']");
+	let name = text(".entity-result__title-text a span[aria-hidden='true']") ||
+		text("span[dir='ltr'] span[aria-hidden='true']");
+	if (!name && link) name = link.innerText.split('\n')[0].trim();
+	const buttons = Array.from(this.querySelectorAll('button')).map(b =>
+		((b.innerText || '') + '|' + (b.getAttribute('aria-label') || '')).trim());
+	return {
+		href: link ? link.getAttribute('href') : '',
+		name: name,
+		headline: text('.entity-result__primary-subtitle') || text('div.t-14.t-black.t-normal'),
+		location: text('.entity-result__secondary-subtitle') || text('div.t-14.t-normal:not(.t-black)'),
+		badge: text('.entity-result__badge-text') || text("span.entity-result__badge") || text("[class*='badge']"),
+		buttons: buttons,
+	};
+}`
+
+type rawCard struct {
+	Href     string   `json:"href"`
+	Name     string   `json:"name"`
+	Headline string   `json:"headline"`
+	Location string   `json:"location"`
+	Badge    string   `json:"badge"`
+	Buttons  []string `json:"buttons"`
+}
+
+// cardElements returns the result cards on the current search page
+func cardElements(page *rod.Page) rod.Elements {
+	for _, sel := range cardSelectors {
+		els, err := page.Elements(sel)
+		if err == nil && len(els) > 0 {
+			var cards rod.Elements
+			for _, el := range els {
+				// skip modules rendered in the right rail
+				if inAside, err := el.Eval(`function() { return !!this.closest('aside') }`); err == nil && inAside.Value.Bool() {
+					continue
+				}
+				cards = append(cards, el)
+			}
+			return cards
+		}
+	}
+	return nil
+}
+
+// readCard extracts a Card from a result card element
+func readCard(el *rod.Element) (Card, bool) {
+	obj, err := el.Eval(readCardJS)
+	if err != nil {
+		return Card{}, false
+	}
+	var raw rawCard
+	if err := obj.Value.Unmarshal(&raw); err != nil {
+		return Card{}, false
+	}
+
+	id, err := profileurl.Parse(raw.Href)
+	if err != nil {
+		return Card{}, false
+	}
+
+	card := Card{
+		Identity: id,
+		Name:     utils.FirstLine(raw.Name),
+		Headline: utils.FirstLine(raw.Headline),
+		Location: utils.FirstLine(raw.Location),
+		Degree:   degreePattern.FindString(raw.Badge),
+	}
+	for _, b := range raw.Buttons {
+		label, aria, _ := strings.Cut(b, "|")
+		label = strings.TrimSpace(label)
+		switch {
+		case label == "Connect" || strings.HasPrefix(aria, "Invite "):
+			card.HasConnect = true
+		case label == "Pending" || strings.HasPrefix(aria, "Pending"):
+			card.HasPending = true
+		case label == "Follow" || strings.HasPrefix(aria, "Follow "):
+			card.HasFollow = true
+		}
+	}
+	return card, true
+}
+
+// the inline Connect button of a card, if it has one
+const cardConnectJS = `function() {
+	return Array.from(this.querySelectorAll('button')).find(b =>
+		(b.innerText || '').trim() === 'Connect' ||
+		(b.getAttribute('aria-label') || '').startsWith('Invite ')) || null;
+}`
+
+// ConnectButton finds the card for id on the current search page and returns
+// its inline Connect button, or nil when the card is gone or has none
+func ConnectButton(page *rod.Page, id profileurl.Identity) *rod.Element {
+	for _, el := range cardElements(page) {
+		card, ok := readCard(el)
+		if !ok || !card.Identity.Matches(id) || !card.HasConnect {
+			continue
+		}
+		btn, err := el.ElementByJS(rod.Eval(cardConnectJS))
+		if err != nil {
+			return nil
+		}
+		return btn
+	}
+	return nil
+}
+
+func scrapeCurrentPage(page *rod.Page) []Card {
+	var cards []Card
+	for _, el := range cardElements(page) {
+		if card, ok := readCard(el); ok {
+			cards = append(cards, card)
+		}
+	}
+	return cards
+}
+
+// FirstName is the first word of the card's name, for note templates
+func (c Card) FirstName() string {
+	first, _, _ := strings.Cut(c.Name, " ")
+	return first
+}
+
+// Vars are the note template variables known from the card
+func (c Card) Vars() map[string]string {
+	vars := map[string]string{}
+	if c.Name != "" {
+		vars["name"] = c.Name
+		vars["firstName"] = c.FirstName()
+	}
+	if c.Headline != "" {
+		vars["headline"] = c.Headline
+	}
+	if c.Location != "" {
+		vars["location"] = c.Location
+	}
+	return vars
+}
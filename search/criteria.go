@@ -0,0 +1,144 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const peopleSearchURL = "https://www.linkedin.com/search/results/people/"
+
+// Criteria are the people-search filters, mapped onto LinkedIn's facet parameters
+type Criteria struct {
+	Keywords        string   `json:"keywords,omitempty"`
+	Network         []string `json:"network,omitempty"`         // "2nd", "3rd"
+	Geo             []string `json:"geo,omitempty"`             // geo URN IDs
+	CurrentCompany  []string `json:"currentCompany,omitempty"`  // company IDs
+	PastCompany     []string `json:"pastCompany,omitempty"`     // company IDs
+	Industry        []string `json:"industry,omitempty"`        // industry IDs
+	School          []string `json:"school,omitempty"`          // school IDs
+	Title           string   `json:"title,omitempty"`           // free-text current title
+	ProfileLanguage []string `json:"profileLanguage,omitempty"` // ISO 639-1 codes
+}
+
+var (
+	networkFacets = map[string]string{"2nd": "S", "3rd": "O"}
+	numericID     = regexp.MustCompile(`^[0-9]+$`)
+	languageCode  = regexp.MustCompile(`^[a-z]{2}$`)
+)
+
+func (c Criteria) IsEmpty() bool {
+	return strings.TrimSpace(c.Keywords) == "" && strings.TrimSpace(c.Title) == "" &&
+		len(c.Network) == 0 && len(c.Geo) == 0 && len(c.CurrentCompany) == 0 &&
+		len(c.PastCompany) == 0 && len(c.Industry) == 0 && len(c.School) == 0 &&
+		len(c.ProfileLanguage) == 0
+}
+
+func (c Criteria) Validate() error {
+	if c.IsEmpty() {
+		return errors.New("search criteria: keywords or at least one filter is required")
+	}
+	for _, n := range c.Network {
+		if _, ok := networkFacets[n]; !ok {
+			return fmt.Errorf("search criteria: network %q must be 2nd or 3rd", n)
+		}
+	}
+	ids := map[string][]string{
+		"geo":            c.Geo,
+		"currentCompany": c.CurrentCompany,
+		"pastCompany":    c.PastCompany,
+		"industry":       c.Industry,
+		"school":         c.School,
+	}
+	for name, values := range ids {
+		for _, v := range values {
+			if !numericID.MatchString(v) {
+				return fmt.Errorf("search criteria: %s %q must be a numeric LinkedIn ID", name, v)
+			}
+		}
+	}
+	for _, lang := range c.ProfileLanguage {
+		if !languageCode.MatchString(lang) {
+			return fmt.Errorf("search criteria: profileLanguage %q must be a two-letter code", lang)
+		}
+	}
+	if len(c.Keywords) > 200 || len(c.Title) > 100 {
+		return errors.New("search criteria: keywords or title too long")
+	}
+	return nil
+}
+
+// String summarises the criteria for logs
+func (c Criteria) String() string {
+	var parts []string
+	if c.Keywords != "" {
+		parts = append(parts, strconv.Quote(c.Keywords))
+	}
+	add := func(name string, values []string) {
+		if len(values) > 0 {
+			parts = append(parts, name+"="+strings.Join(values, ","))
+		}
+	}
+	add("network", c.Network)
+	add("geo", c.Geo)
+	add("currentCompany", c.CurrentCompany)
+	add("pastCompany", c.PastCompany)
+	add("industry", c.Industry)
+	add("school", c.School)
+	if c.Title != "" {
+		parts = append(parts, "title="+strconv.Quote(c.Title))
+	}
+	add("language", c.ProfileLanguage)
+	return strings.Join(parts, " ")
+}
+
+// BuildURL returns the people-search URL for the criteria and 1-based result page
+func BuildURL(c Criteria, page int) (string, error) {
+	if err := c.Validate(); err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	if c.Keywords != "" {
+		q.Set("keywords", c.Keywords)
+	}
+
+	var network []string
+	for _, n := range c.Network {
+		network = append(network, networkFacets[n])
+	}
+	setFacet(q, "network", network)
+	setFacet(q, "geoUrn", c.Geo)
+	setFacet(q, "currentCompany", c.CurrentCompany)
+	setFacet(q, "pastCompany", c.PastCompany)
+	setFacet(q, "industry", c.Industry)
+	setFacet(q, "schoolFilter", c.School)
+	setFacet(q, "profileLanguage", c.ProfileLanguage)
+
+	if c.Title != "" {
+		q.Set("titleFreeText", c.Title)
+	}
+	if len(q) > 1 || q.Get("keywords") == "" {
+		q.Set("origin", "FACETED_SEARCH")
+	} else {
+		q.Set("origin", "GLOBAL_SEARCH_HEADER")
+	}
+	if page > 1 {
+		q.Set("page", strconv.Itoa(page))
+	}
+
+	return peopleSearchURL + "?" + q.Encode(), nil
+}
+
+// facets are encoded as JSON string arrays, e.g. network=["S","O"]
+func setFacet(q url.Values, name string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	data, _ := json.Marshal(values)
+	q.Set(name, string(data))
+}
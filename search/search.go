@@ -1,148 +1,171 @@
-package search
-
-import (
-	"fmt"
-	"net/url"
-	"strings"
-	"time"
-
-	"github.com/meetm/linkedin-automation-go/pkg/logger"
-	"github.com/meetm/linkedin-automation-go/utils"
-
-	"github.com/go-rod/rod"
-)
-
-func Run(page *rod.Page, keyword string, limit int, log *logger.Logger) []string {
-	log.Printf("Searching for: %s", keyword)
-
-	searchURL := fmt.Sprintf(
-		"https://www.linkedin.com/search/results/people/?keywords=%s",
-		url.QueryEscape(keyword),
-	)
-
-	if err := page.Navigate(searchURL); err != nil {
-		log.Printf("Navigation error: %v", err)
-		return nil
-	}
-
-	utils.LongRandomSleep(3, 5)
-
-	if err := page.WaitStable(time.Second * 5); err != nil {
-		log.Printf("Page stability warning: %v", err)
-	}
-
-	log.Printf("Search page loaded: %s", page.MustInfo().URL)
-
-	if hasNoResults(page) {
-		log.Printf("No search results found")
-		return nil
-	}
-
-	var allProfiles []string
-	visited := make(map[string]bool)
-	pageNum := 1
-
-	for len(allProfiles) < limit {
-		log.Printf("Scanning page %d...", pageNum)
-
-		utils.HumanScroll(page, 300)
-		utils.RandomSleep(500, 1000)
-
-		profiles := scrapeCurrentPage(page)
-
-		if len(profiles) == 0 {
-			log.Printf("No profiles found, scrolling further...")
-			utils.HumanScroll(page, 800)
-			utils.LongRandomSleep(1, 2)
-			profiles = scrapeCurrentPage(page)
-		}
-
-		for _, profileURL := range profiles {
-			if visited[profileURL] {
-				continue
-			}
-			visited[profileURL] = true
-			allProfiles = append(allProfiles, profileURL)
-			log.Printf("Found: %s", profileURL)
-
-			if len(allProfiles) >= limit {
-				break
-			}
-		}
-
-		if len(allProfiles) >= limit {
-			break
-		}
-
-		utils.HumanScroll(page, 1500)
-		utils.RandomSleep(800, 1500)
-
-		if !goToNextPage(page, log) {
-			break
-		}
-
-		pageNum++
-		utils.LongRandomSleep(2, 4)
-	}
-
-	log.Printf("Collected %d profiles", len(allProfiles))
-	return allProfiles
-}
-
-func hasNoResults(page *rod.Page) bool {
-	el, err := page.Timeout(3*time.Second).ElementR("div", "No results found")
-	if err != nil {
-		return false
-	}
-	return utils.IsElementVisible(el)
-}
-
-func goToNextPage(page *rod.Page, log *logger.Logger) bool {
-	nextBtn, err := page.Timeout(5 * time.Second).Element("button[aria-label='Next']")
-	if err != nil {
-		log.Printf("No next page available")
-		return false
-	}
-
-	disabled, _ := nextBtn.Attribute("disabled")
-	if disabled != nil {
-		log.Printf("Reached last page")
-		return false
-	}
-
-	if err := utils.HumanClick(page, nextBtn); err != nil {
-		log.Printf("Failed to click next: %v", err)
-		return false
-	}
-
-	log.Printf("Loading next page...")
-	page.MustWaitStable()
-	return true
-}
-
-func scrapeCurrentPage(page *rod.Page) []string {
-	var urls []string
-	links, err := page.Elements("a")
-	if err != nil {
-		return urls
-	}
-
-	for _, link := range links {
-		hrefPtr, err := link.Attribute("href")
-		if err != nil || hrefPtr == nil {
-			continue
-		}
-
-		href := strings.Split(*hrefPtr, "?")[0]
-
-		if strings.HasPrefix(href, "/in/") {
-			href = "https://www.linkedin.com" + href
-		}
-
-		if strings.Contains(href, "linkedin.com/in/") && !strings.Contains(href, "/search/") {
-			urls = append(urls, href)
-		}
-	}
-
-	return urls
-}
+package search
+
+import (
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/profileurl"
+	"github.com/meetm/linkedin-automation-go/utils"
+
+	"github.com/go-rod/rod"
+)
+
+// Options control how many cards a search collects and where it starts
+type Options struct {
+	Limit  int
+	Filter Filter // nil keeps all cards
+
+	// resume a search at StartPage with the cards already collected
+	StartPage int
+	Found     []Card
+
+	// OnPage is called after each results page with the next page to scan
+	OnPage func(nextPage int, found []Card)
+}
+
+// Run collects up to opts.Limit result cards accepted by opts.Filter. The
+// error is that of opening the first results page; later pages that fail
+// to load end the search with what was found.
+func Run(page *rod.Page, criteria Criteria, opts Options, log *logger.Logger) ([]Card, error) {
+	log.Printf("Searching for: %s", criteria)
+
+	limit := opts.Limit
+	allProfiles := append([]Card(nil), opts.Found...)
+	pageNum := max(opts.StartPage, 1)
+	if len(allProfiles) >= limit {
+		return allProfiles, nil
+	}
+
+	if err := OpenPage(page, criteria, pageNum, log); err != nil {
+		log.Printf("Search failed: %v", err)
+		return allProfiles, err
+	}
+
+	if hasNoResults(page) {
+		log.Printf("No search results found")
+		return allProfiles, nil
+	}
+
+	var rejected []Card
+
+	for len(allProfiles) < limit {
+		log.Printf("Scanning page %d...", pageNum)
+
+		utils.HumanScroll(page, 300)
+		utils.RandomSleep(500, 1000)
+
+		profiles := scrapeCurrentPage(page)
+
+		if len(profiles) == 0 {
+			log.Printf("No profiles found, scrolling further...")
+			utils.HumanScroll(page, 800)
+			utils.LongRandomSleep(1, 2)
+			profiles = scrapeCurrentPage(page)
+		}
+
+		for _, card := range profiles {
+			card.Page = pageNum
+			if contains(allProfiles, card.Identity) || contains(rejected, card.Identity) {
+				continue
+			}
+			if opts.Filter != nil {
+				if keep, reason := opts.Filter(card); !keep {
+					log.Printf("Skipping %s: %s", card.Name, reason)
+					rejected = append(rejected, card)
+					continue
+				}
+			}
+			allProfiles = append(allProfiles, card)
+			log.Printf("Found: %s (%s) %s", card.Name, card.Degree, card.Identity.URL())
+
+			if len(allProfiles) >= limit {
+				break
+			}
+		}
+
+		if len(allProfiles) >= limit {
+			break
+		}
+
+		if opts.OnPage != nil {
+			opts.OnPage(pageNum+1, allProfiles)
+		}
+
+		utils.HumanScroll(page, 1500)
+		utils.RandomSleep(800, 1500)
+
+		if !goToNextPage(page, log) {
+			break
+		}
+
+		pageNum++
+		utils.LongRandomSleep(2, 4)
+	}
+
+	log.Printf("Collected %d profiles", len(allProfiles))
+	return allProfiles, nil
+}
+
+// OpenPage navigates to the given page of results for criteria
+func OpenPage(page *rod.Page, criteria Criteria, pageNum int, log *logger.Logger) error {
+	searchURL, err := BuildURL(criteria, pageNum)
+	if err != nil {
+		return err
+	}
+
+	if err := utils.Navigate(page, searchURL, log); err != nil {
+		return err
+	}
+
+	utils.LongRandomSleep(3, 5)
+
+	if err := utils.WaitStable(page, time.Second*5, log); err != nil {
+		log.Printf("Page stability warning: %v", err)
+	}
+
+	log.Printf("Search page loaded: %s", searchURL)
+	return nil
+}
+
+func contains(cards []Card, id profileurl.Identity) bool {
+	for _, seen := range cards {
+		if seen.Identity.Matches(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasNoResults(page *rod.Page) bool {
+	el, err := page.Timeout(3*time.Second).ElementR("div", "No results found")
+	if err != nil {
+		return false
+	}
+	return utils.IsElementVisible(el)
+}
+
+func goToNextPage(page *rod.Page, log *logger.Logger) bool {
+	nextBtn, err := page.Timeout(5 * time.Second).Element("button[aria-label='Next']")
+	if err != nil {
+		log.Printf("No next page available")
+		return false
+	}
+
+	disabled, _ := nextBtn.Attribute("disabled")
+	if disabled != nil {
+		log.Printf("Reached last page")
+		return false
+	}
+
+	if err := utils.HumanClick(page, nextBtn); err != nil {
+		log.Printf("Failed to click next: %v", err)
+		return false
+	}
+
+	log.Printf("Loading next page...")
+	if err := utils.WaitStable(page, time.Second, log); err != nil {
+		log.Printf("Next page did not load: %v", err)
+		return false
+	}
+	return true
+}
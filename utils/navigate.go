@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/meetm/linkedin-automation-go/pkg/logger"
+	"github.com/meetm/linkedin-automation-go/pkg/outcome"
+	"github.com/meetm/linkedin-automation-go/pkg/retry"
+
+	"github.com/go-rod/rod"
+)
+
+const (
+	loadTimeout   = 30 * time.Second // per navigation attempt
+	stableTimeout = 15 * time.Second // per stability wait attempt
+)
+
+// path prefixes of where LinkedIn sends a session that is no longer signed in
+var signInPaths = []string{"/login", "/authwall", "/checkpoint/", "/uas/login", "/signup/"}
+
+// IsSignInURL reports whether rawURL is a LinkedIn login, authwall or
+// checkpoint page. Only the path is matched, so profiles such as
+// /in/loginov are not mistaken for one.
+func IsSignInURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	for _, prefix := range signInPaths {
+		if strings.HasPrefix(u.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Navigate opens url and waits for it to load, retrying transient failures
+// under retry.Navigation. Failures are returned as *outcome.NavigationError;
+// landing on a sign-in page instead of url wraps outcome.ErrSessionExpired.
+func Navigate(page *rod.Page, url string, log *logger.Logger) error {
+	err := retry.Do(page.GetContext(), retry.Navigation, log, "Loading "+url, func() error {
+		p := page.Timeout(loadTimeout)
+		defer p.CancelTimeout()
+		if err := p.Navigate(url); err != nil {
+			return err
+		}
+		return p.WaitLoad()
+	})
+	if err == nil && !IsSignInURL(url) {
+		if info, infoErr := page.Info(); infoErr == nil && IsSignInURL(info.URL) {
+			log.Printf("Redirected to %s, the session has expired", info.URL)
+			err = outcome.ErrSessionExpired
+		}
+	}
+	if err != nil {
+		return &outcome.NavigationError{URL: url, Err: err}
+	}
+	return nil
+}
+
+// WaitStable waits until the page has not changed for d, retrying under
+// retry.Wait when a wait times out
+func WaitStable(page *rod.Page, d time.Duration, log *logger.Logger) error {
+	return retry.Do(page.GetContext(), retry.Wait, log, "Waiting for the page", func() error {
+		p := page.Timeout(stableTimeout)
+		defer p.CancelTimeout()
+		return p.WaitStable(d)
+	})
+}
@@ -0,0 +1,10 @@
+package utils
+
+import "strings"
+
+// FirstLine returns the first non-blank line of text scraped from the page,
+// where a name is often followed by badges or screen-reader text
+func FirstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(line)
+}